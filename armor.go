@@ -0,0 +1,32 @@
+package scheme
+
+import (
+	"bytes"
+	"encoding/pem"
+)
+
+// Types of the armored blocks of the binary formats.
+const (
+	ArmorKeychain           = "HERATIO KEYCHAIN"             // Keychain.MarshalBinary.
+	ArmorSecretKey          = "HERATIO SECRET KEY"           // SecretKey.MarshalBinary.
+	ArmorPublicKey          = "HERATIO PUBLIC KEY"           // PublicKey.MarshalBinary.
+	ArmorEvaluationKey      = "HERATIO EVALUATION KEY"       // EvaluationKey.MarshalBinary.
+	ArmorEncryptedSecretKey = "HERATIO ENCRYPTED SECRET KEY" // SecretKey.Export.
+	ArmorCiphertext         = "HERATIO CIPHERTEXT"           // Ciphertext.MarshalBinary.
+)
+
+// Armor encodes data in a PEM block of the given type, i.e., as base64 text
+// between "-----BEGIN <type>-----" and "-----END <type>-----" lines.
+func Armor(blockType string, data []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data})
+}
+
+// Dearmor decodes the data of a PEM block of the given type encoded by Armor. The
+// text must hold that single block, up to surrounding white space.
+func Dearmor(blockType string, text []byte) ([]byte, error) {
+	b, rest := pem.Decode(text)
+	if b == nil || b.Type != blockType || len(b.Headers) != 0 || len(bytes.TrimSpace(rest)) != 0 {
+		return nil, ErrArmorIsNotValid
+	}
+	return b.Bytes, nil
+}
@@ -0,0 +1,99 @@
+package scheme
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestArmor(t *testing.T) {
+	for name, kc := range testKeychains(t) {
+		// Case: keychain.
+		data, err := kc.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		text := Armor(ArmorKeychain, data)
+		if !bytes.HasPrefix(text, []byte("-----BEGIN "+ArmorKeychain+"-----\n")) {
+			t.Errorf("expected an armored keychain for %s but got %.40s", name, text)
+		}
+		dd, err := Dearmor(ArmorKeychain, text)
+		if err != nil {
+			t.Error(err)
+		}
+		dkc := new(Keychain)
+		if err := dkc.UnmarshalBinary(dd); err != nil {
+			t.Error(err)
+		}
+		if err := equalKeychain(kc, dkc); err != nil {
+			t.Error(err)
+		}
+		// Case: public key.
+		if data, err = kc.PublicKey().MarshalBinary(); err != nil {
+			t.Error(err)
+		}
+		if dd, err = Dearmor(ArmorPublicKey, Armor(ArmorPublicKey, data)); err != nil {
+			t.Error(err)
+		}
+		pk := new(PublicKey)
+		if err := pk.UnmarshalBinary(dd); err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(pk.Value[0], kc.PK[0]) || !equalPolys(pk.Value[1], kc.PK[1]) {
+			t.Errorf("expected the public key of %s to be restored", name)
+		}
+		// Case: ciphertext.
+		cip, err := NewCipher(kc)
+		if err != nil {
+			t.Error(err)
+		}
+		m := make([]*big.Int, kc.Params.Size())
+		for i := 0; i < len(m); i++ {
+			m[i] = big.NewInt(int64(i % 3))
+		}
+		ct, err := cip.Enc(NewPlaintext(m, CodecNone, kc.Params))
+		if err != nil {
+			t.Error(err)
+		}
+		if data, err = ct.MarshalBinary(); err != nil {
+			t.Error(err)
+		}
+		if dd, err = Dearmor(ArmorCiphertext, Armor(ArmorCiphertext, data)); err != nil {
+			t.Error(err)
+		}
+		dct, err := UnmarshalCiphertext(dd, kc.Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mr, err := cip.Dec(dct)
+		if err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(mr.Value, m) {
+			t.Errorf("expected the armored ciphertext of %s to decrypt to the message", name)
+		}
+	}
+}
+
+func TestDearmorErrors(t *testing.T) {
+	text := Armor(ArmorCiphertext, []byte{1, 2, 3})
+	tests := []struct {
+		name string
+		text []byte
+	}{
+		{"empty", nil},
+		{"no block", []byte("HRCT")},
+		{"other type", Armor(ArmorPublicKey, []byte{1, 2, 3})},
+		{"trailing data", append(append([]byte{}, text...), "garbage"...)},
+		{"two blocks", append(append([]byte{}, text...), text...)},
+	}
+	for _, tt := range tests {
+		if _, err := Dearmor(ArmorCiphertext, tt.text); err != ErrArmorIsNotValid {
+			t.Errorf("expected error %s for %s but got %v", ErrArmorIsNotValid, tt.name, err)
+		}
+	}
+	// Case: surrounding white space is allowed.
+	if data, err := Dearmor(ArmorCiphertext, append(append([]byte("\n"), text...), '\n')); err != nil || !bytes.Equal(data, []byte{1, 2, 3}) {
+		t.Errorf("expected the armored data to be decoded but got %v (%v)", data, err)
+	}
+}
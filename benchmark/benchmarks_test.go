@@ -8,6 +8,7 @@ import (
 	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
 	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/rns"
 	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
 )
 
@@ -99,7 +100,7 @@ func BenchmarkHeratioEncrypt(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message.
-	m := lc.Enc(params.M0)
+	m := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -129,7 +130,7 @@ func BenchmarkBFVEncrypt(b *testing.B) {
 		b.Error(err)
 	}
 	// Message.
-	m := sc.Enc(params.M0)
+	m := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -157,7 +158,7 @@ func BenchmarkHeratioDecrypt(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message.
-	m := lc.Enc(params.M0)
+	m := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -189,7 +190,7 @@ func BenchmarkBFVDecrypt(b *testing.B) {
 		b.Error(err)
 	}
 	// Message.
-	m := sc.Enc(params.M0)
+	m := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -219,9 +220,9 @@ func BenchmarkHeratioCiphertextScalarAddition(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message.
-	m := lc.Enc(params.M0)
+	m := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Additive scalar.
-	as := lc.Enc(params.AS)
+	as := scheme.NewPlaintext(lc.Enc(params.AS), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -261,9 +262,9 @@ func BenchmarkBFVCiphertextScalarAddition(b *testing.B) {
 		b.Error(err)
 	}
 	// Message.
-	m := sc.Enc(params.M0)
+	m := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Additive scalar.
-	as := sc.Enc(params.AS)
+	as := scheme.NewPlaintext(sc.Enc(params.AS), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -301,9 +302,9 @@ func BenchmarkHeratioCiphertextAddition(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message 0.
-	m0 := lc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Message 1.
-	m1 := lc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(lc.Enc(params.M1), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -344,9 +345,9 @@ func BenchmarkBFVCiphertextAddition(b *testing.B) {
 		b.Error(err)
 	}
 	// Message 0.
-	m0 := sc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Message 1.
-	m1 := sc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(sc.Enc(params.M1), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -385,7 +386,7 @@ func BenchmarkHeratioCiphertextScalarMultiplication(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message.
-	m := lc.Enc(params.M0)
+	m := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Multiplicative scalar.
 	ms := big.NewInt(params.MS)
 	// Cipher.
@@ -424,7 +425,7 @@ func BenchmarkBFVCiphertextScalarMultiplication(b *testing.B) {
 		b.Error(err)
 	}
 	// Message.
-	m := sc.Enc(params.M0)
+	m := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Multiplicative scalar.
 	ms := big.NewInt(params.MS)
 	// Cipher.
@@ -445,6 +446,82 @@ func BenchmarkBFVCiphertextScalarMultiplication(b *testing.B) {
 	}
 }
 
+func BenchmarkHeratioCiphertextPlaintextMultiplication(b *testing.B) {
+	// Parameters.
+	p, err := params.New(params.PLHERatio16)
+	if err != nil {
+		b.Error(err)
+	}
+	// Oracle.
+	o := new(oracle.Oracle)
+	// Keychain.
+	kc, err := scheme.NewKeychain(o, p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Laurent codes.
+	lc := laurent.New(p)
+	// Message.
+	m := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
+	// Plaintext.
+	pt := scheme.NewPlaintext(lc.Enc(params.M1), scheme.CodecLaurent, p)
+	// Cipher.
+	cip, err := scheme.NewCipher(kc)
+	if err != nil {
+		b.Error(err)
+	}
+	// Encrypt.
+	c, err := cip.Enc(m)
+	if err != nil {
+		b.Error(err)
+	}
+	// Evaluator.
+	eval := scheme.NewEvaluator(kc)
+	// Benchmark.
+	for i := 0; i < b.N; i++ {
+		eval.PMult(c, pt)
+	}
+}
+
+func BenchmarkBFVCiphertextPlaintextMultiplication(b *testing.B) {
+	// SIM2D codec.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		b.Error(err)
+	}
+	sc, err := sim2d.New(p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Oracle.
+	o := new(oracle.Oracle)
+	// Keychain.
+	kc, err := scheme.NewKeychain(o, p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Message.
+	m := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
+	// Plaintext.
+	pt := scheme.NewPlaintext(sc.Enc(params.M1), scheme.CodecSIM2D, p)
+	// Cipher.
+	cip, err := scheme.NewCipher(kc)
+	if err != nil {
+		b.Error(err)
+	}
+	// Encrypt.
+	c, err := cip.Enc(m)
+	if err != nil {
+		b.Error(err)
+	}
+	// Evaluator.
+	eval := scheme.NewEvaluator(kc)
+	// Benchmark.
+	for i := 0; i < b.N; i++ {
+		eval.PMult(c, pt)
+	}
+}
+
 func BenchmarkHeratioCiphertextMultiplication(b *testing.B) {
 	// Parameters.
 	p, err := params.New(params.PLHERatio16)
@@ -461,9 +538,9 @@ func BenchmarkHeratioCiphertextMultiplication(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message 0.
-	m0 := lc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Message 1.
-	m1 := lc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(lc.Enc(params.M1), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -507,9 +584,9 @@ func BenchmarkBFVCiphertextMultiplication(b *testing.B) {
 		b.Error(err)
 	}
 	// Message 0.
-	m0 := sc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Message 1.
-	m1 := sc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(sc.Enc(params.M1), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -535,6 +612,85 @@ func BenchmarkBFVCiphertextMultiplication(b *testing.B) {
 	}
 }
 
+func BenchmarkRNSHeratioCiphertextMultiplication(b *testing.B) {
+	// Parameters.
+	p, err := params.New(params.PLHERatio16)
+	if err != nil {
+		b.Error(err)
+	}
+	// Keychain.
+	kc, err := rnsKeychainSetup(p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Laurent codes.
+	lc := laurent.New(p)
+	// Cipher.
+	cip, err := rns.NewCipher(kc)
+	if err != nil {
+		b.Error(err)
+	}
+	// Encrypt.
+	c0, err := cip.Enc(lc.Enc(params.M0))
+	if err != nil {
+		b.Error(err)
+	}
+	c1, err := cip.Enc(lc.Enc(params.M1))
+	if err != nil {
+		b.Error(err)
+	}
+	// Evaluator.
+	eval := rns.NewEvaluator(kc)
+	// Benchmark.
+	for i := 0; i < b.N; i++ {
+		_, err := eval.Mult(c0, c1)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkRNSBFVCiphertextMultiplication(b *testing.B) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		b.Error(err)
+	}
+	// SIM2D codec.
+	sc, err := sim2d.New(p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Keychain.
+	kc, err := rnsKeychainSetup(p)
+	if err != nil {
+		b.Error(err)
+	}
+	// Cipher.
+	cip, err := rns.NewCipher(kc)
+	if err != nil {
+		b.Error(err)
+	}
+	// Encrypt.
+	c0, err := cip.Enc(sc.Enc(params.M0))
+	if err != nil {
+		b.Error(err)
+	}
+	c1, err := cip.Enc(sc.Enc(params.M1))
+	if err != nil {
+		b.Error(err)
+	}
+	// Evaluator.
+	eval := rns.NewEvaluator(kc)
+	// Benchmark.
+	for i := 0; i < b.N; i++ {
+		_, err := eval.Mult(c0, c1)
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 func BenchmarkHeratioCiphertextPolynomialMultiplication(b *testing.B) {
 	// Parameters.
 	p, err := params.New(params.PLHERatio16)
@@ -551,9 +707,9 @@ func BenchmarkHeratioCiphertextPolynomialMultiplication(b *testing.B) {
 	// Laurent codes.
 	lc := laurent.New(p)
 	// Message 0.
-	m0 := lc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(lc.Enc(params.M0), scheme.CodecLaurent, p)
 	// Message 1.
-	m1 := lc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(lc.Enc(params.M1), scheme.CodecLaurent, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -570,19 +726,19 @@ func BenchmarkHeratioCiphertextPolynomialMultiplication(b *testing.B) {
 	}
 	// Benchmark.
 	for i := 0; i < b.N; i++ {
-		_, err := scheme.HERatioPolyMult(c0[0], c1[0], p)
+		_, err := scheme.HERatioPolyMult(c0.Value[0], c1.Value[0], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.HERatioPolyMult(c0[0], c1[1], p)
+		_, err = scheme.HERatioPolyMult(c0.Value[0], c1.Value[1], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.HERatioPolyMult(c0[1], c1[0], p)
+		_, err = scheme.HERatioPolyMult(c0.Value[1], c1.Value[0], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.HERatioPolyMult(c0[1], c1[1], p)
+		_, err = scheme.HERatioPolyMult(c0.Value[1], c1.Value[1], p)
 		if err != nil {
 			b.Error(err)
 		}
@@ -607,9 +763,9 @@ func BenchmarkBFVCiphertextPolynomialMultiplication(b *testing.B) {
 		b.Error(err)
 	}
 	// Message 0.
-	m0 := sc.Enc(params.M0)
+	m0 := scheme.NewPlaintext(sc.Enc(params.M0), scheme.CodecSIM2D, p)
 	// Message 1.
-	m1 := sc.Enc(params.M1)
+	m1 := scheme.NewPlaintext(sc.Enc(params.M1), scheme.CodecSIM2D, p)
 	// Cipher.
 	cip, err := scheme.NewCipher(kc)
 	if err != nil {
@@ -626,19 +782,19 @@ func BenchmarkBFVCiphertextPolynomialMultiplication(b *testing.B) {
 	}
 	// Benchmark.
 	for i := 0; i < b.N; i++ {
-		_, err := scheme.BFVPolyMult(c0[0], c1[0], p)
+		_, err := scheme.BFVPolyMult(c0.Value[0], c1.Value[0], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.BFVPolyMult(c0[0], c1[1], p)
+		_, err = scheme.BFVPolyMult(c0.Value[0], c1.Value[1], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.BFVPolyMult(c0[1], c1[0], p)
+		_, err = scheme.BFVPolyMult(c0.Value[1], c1.Value[0], p)
 		if err != nil {
 			b.Error(err)
 		}
-		_, err = scheme.BFVPolyMult(c0[1], c1[1], p)
+		_, err = scheme.BFVPolyMult(c0.Value[1], c1.Value[1], p)
 		if err != nil {
 			b.Error(err)
 		}
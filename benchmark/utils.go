@@ -4,6 +4,7 @@ import (
 	scheme "github.com/Algemetric/HERatio/Implementation/Golang"
 	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/rns"
 )
 
 func keychainSetup(pl params.Literal) (*scheme.Keychain, error) {
@@ -23,3 +24,21 @@ func keychainSetup(pl params.Literal) (*scheme.Keychain, error) {
 
 	return kc, nil
 }
+
+// rnsLimbs is the number of primes in the RNS coefficient modulus for benchmarks.
+const rnsLimbs = 3
+
+func rnsKeychainSetup(p *params.Params) (*rns.Keychain, error) {
+	// Primes of the coefficient modulus.
+	primes, err := rns.NTTPrimes(50, p.Size(), rnsLimbs)
+	if err != nil {
+		return nil, err
+	}
+	// RNS parameters.
+	rp, err := rns.NewParams(p, primes)
+	if err != nil {
+		return nil, err
+	}
+	// Keychain.
+	return rns.NewKeychain(new(oracle.Oracle), rp)
+}
@@ -1,89 +1,235 @@
 package scheme
 
 import (
+	"math"
 	"math/big"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
 )
 
+// Encryptor encrypts encoded messages with a public key.
+type Encryptor struct {
+	pk   *PublicKey        // Public key.
+	o    oracle.Randomizer // Random source.
+	ring *Ring             // Ring context.
+}
+
+// Decryptor decrypts ciphertexts back into code messages with a secret key.
+type Decryptor struct {
+	sk   *SecretKey // Secret key.
+	ring *Ring      // Ring context.
+}
+
+// SymmetricEncryptor encrypts encoded messages with a secret key, which gives a
+// lower noise than the public key when the data owner encrypts its own data.
+type SymmetricEncryptor struct {
+	sk   *SecretKey        // Secret key.
+	o    oracle.Randomizer // Random source.
+	ring *Ring             // Ring context.
+}
+
 // Cipher is the structure that encrypts encoded messages,
 // and decrypts ciphertexts back into code messages.
 type Cipher struct {
-	kc *Keychain // Structure for key generation and random sources.
+	*Encryptor
+	*Decryptor
+}
+
+// NewEncryptor creates an encryptor with a public key and a source for randomness.
+func NewEncryptor(pk *PublicKey, o oracle.Randomizer) (*Encryptor, error) {
+	if pk == nil || pk.Params == nil || len(pk.Value) != 2 {
+		return nil, ErrKeyIsNotValid
+	}
+	return &Encryptor{pk: pk, o: o, ring: NewRing(pk.Params)}, nil
+}
+
+// NewDecryptor creates a decryptor with a secret key.
+func NewDecryptor(sk *SecretKey) (*Decryptor, error) {
+	if sk == nil || sk.Params == nil || len(sk.Value) != sk.Params.Size() {
+		return nil, ErrKeyIsNotValid
+	}
+	return &Decryptor{sk: sk, ring: NewRing(sk.Params)}, nil
+}
+
+// NewSymmetricEncryptor creates an encryptor with a secret key and a source for randomness.
+func NewSymmetricEncryptor(sk *SecretKey, o oracle.Randomizer) (*SymmetricEncryptor, error) {
+	if sk == nil || sk.Params == nil || len(sk.Value) != sk.Params.Size() {
+		return nil, ErrKeyIsNotValid
+	}
+	return &SymmetricEncryptor{sk: sk, o: o, ring: NewRing(sk.Params)}, nil
 }
 
 // NewCipher creates a new cipher with a given source for randomness in the keychain.
+// A keychain without the secret (public) key gives a cipher whose Dec (Enc) returns
+// ErrKeyIsNotValid, and a keychain without either key is not valid.
 func NewCipher(kc *Keychain) (*Cipher, error) {
-	return &Cipher{kc: kc}, nil
+	if kc.PK == nil && kc.SK == nil {
+		return nil, ErrKeyIsNotValid
+	}
+	cip := new(Cipher)
+	var err error
+	if kc.PK != nil {
+		if cip.Encryptor, err = NewEncryptor(kc.PublicKey(), kc.O); err != nil {
+			return nil, err
+		}
+	}
+	if kc.SK != nil {
+		if cip.Decryptor, err = NewDecryptor(kc.SecretKey()); err != nil {
+			return nil, err
+		}
+	}
+	return cip, nil
 }
 
-// Enc encrypts an encoded message.
-func (cip *Cipher) Enc(m []*big.Int) ([][]*big.Int, error) {
+// Enc encrypts an encoded message into (pk0*u + e0 + delta*m, pk1*u + e1).
+func (enc *Encryptor) Enc(pt *Plaintext) (*Ciphertext, error) {
+	if enc == nil {
+		return nil, ErrKeyIsNotValid
+	}
 	// Parameters.
-	params := cip.kc.Params
+	params := enc.pk.Params
+	if err := validatePlaintext(pt, params); err != nil {
+		return nil, err
+	}
+	r := enc.ring
 	// Size.
 	n := params.Size()
 	// Sample random numbers.
 	// Lower and upper bounds for random elements.
-	rn, err := cip.kc.O.RandInt(-1, 2, n)
+	rn, err := enc.o.RandInt(-1, 2, n)
 	if err != nil {
 		return nil, err
 	}
+	u := r.Poly(rn)
 	// Samples from a normal distribution.
-	var nd [][]*big.Int
-	nd = append(nd, cip.kc.O.NormDist(n))
-	nd = append(nd, cip.kc.O.NormDist(n))
+	e0 := r.Poly(enc.o.NormDist(n))
+	e1 := r.Poly(enc.o.NormDist(n))
 	// DeltaM.
-	deltaM := make([]*big.Int, n)
-	for i := 0; i < n; i++ {
-		deltaM[i] = big.NewInt(0)
-		deltaM[i].Mul(m[i], Delta(params))
-	}
-	// Multiplication based on the scheme.
-	// Public key.
-	pk := cip.kc.PK
-	p0, err := PolyMult(pk[0], rn, params)
+	dm := r.NewPoly().MulScalar(r.Poly(pt.Value), Delta(params))
+	// Multiplication by the public key.
+	pk := enc.pk.Value
+	c0, err := r.NewPoly().Mul(r.Poly(pk[0]), u)
 	if err != nil {
 		return nil, err
 	}
-	p1, err := PolyMult(pk[1], rn, params)
+	c1, err := r.NewPoly().Mul(r.Poly(pk[1]), u)
 	if err != nil {
 		return nil, err
 	}
-	//
-	p00 := SumZip(p0, nd[0], params)
-	p11 := SumZip(p1, nd[1], params)
-	dp00 := SumZip(deltaM, p00, params)
-	//
-	var c [][]*big.Int
-	cm := big.NewInt(params.CoefficientModulus())
-	c = append(c, VecSymMod(dp00, cm))
-	c = append(c, VecSymMod(p11, cm))
-
-	return c, nil
+	c0.Add(c0, e0).Add(c0, dm).SymModInPlace()
+	c1.Add(c1, e1).SymModInPlace()
+	return newCiphertext([][]*big.Int{c0.Coeffs, c1.Coeffs}, pt.Codec, params.FreshNoise(), params), nil
 }
 
-// Dec decrypts a ciphertext into a SIM2D coded message (code).
-func (cip *Cipher) Dec(c [][]*big.Int) ([]*big.Int, error) {
+// Enc encrypts an encoded message into (-a*s + e + delta*m, a), where a is uniform
+// modulo q. The ciphertext is decrypted and evaluated as the ones encrypted with
+// the public key.
+func (enc *SymmetricEncryptor) Enc(pt *Plaintext) (*Ciphertext, error) {
 	// Parameters.
-	params := cip.kc.Params
-	dm := big.NewInt(params.DecryptionModulus())
-	cm := big.NewInt(params.CoefficientModulus())
+	params := enc.sk.Params
+	if err := validatePlaintext(pt, params); err != nil {
+		return nil, err
+	}
+	r := enc.ring
+	// Size.
+	n := params.Size()
+	// Sample a uniformly in [-ceil((q-1)/2), floor((q-1)/2)].
+	lb, ub := coeffBounds(params)
+	ub.Add(ub, big.NewInt(1))
+	rn, err := enc.o.RandBigInt(lb, ub, n)
+	if err != nil {
+		return nil, err
+	}
+	a := r.Poly(rn)
+	// Samples from a normal distribution.
+	e := r.Poly(enc.o.NormDist(n))
+	// DeltaM.
+	dm := r.NewPoly().MulScalar(r.Poly(pt.Value), Delta(params))
+	// -a*s.
+	c0, err := r.NewPoly().Mul(a, r.Poly(enc.sk.Value))
+	if err != nil {
+		return nil, err
+	}
+	c0.Neg(c0).Add(c0, e).Add(c0, dm).SymModInPlace()
+	return newCiphertext([][]*big.Int{c0.Coeffs, a.Coeffs}, pt.Codec, params.FreshSymNoise(), params), nil
+}
 
-	prod, err := PolyMult(c[1], cip.kc.SK, params)
+// EncSym encrypts an encoded message with the secret key of the cipher, and the
+// random source of its encryptor.
+func (cip *Cipher) EncSym(pt *Plaintext) (*Ciphertext, error) {
+	if cip.Encryptor == nil || cip.Decryptor == nil {
+		return nil, ErrKeyIsNotValid
+	}
+	enc, err := NewSymmetricEncryptor(cip.sk, cip.o)
 	if err != nil {
 		return nil, err
 	}
+	return enc.Enc(pt)
+}
 
-	vsm := VecSymMod(SumZip(c[0], prod, params), cm)
-	for i := 0; i < len(vsm); i++ {
-		vsm[i].Mul(vsm[i], dm)
-		vsm[i] = DivRound(vsm[i], cm)
+// Dec decrypts a ciphertext into a coded message (code), i.e., round(t/q * [c0 + c1*s]_q) mod t.
+func (dec *Decryptor) Dec(ct *Ciphertext) (*Plaintext, error) {
+	if dec == nil {
+		return nil, ErrKeyIsNotValid
+	}
+	// Parameters.
+	params := dec.sk.Params
+	if err := validateCiphertext(ct, params); err != nil {
+		return nil, err
+	}
+	if ct.Degree() != 1 {
+		return nil, ErrCiphertextDegreeIsNotValid
 	}
+	r := dec.ring
+	// c0 + c1*s.
+	x, err := r.NewPoly().Mul(r.Poly(ct.Value[1]), r.Poly(dec.sk.Value))
+	if err != nil {
+		return nil, err
+	}
+	x.Add(x, r.Poly(ct.Value[0])).SymModInPlace()
+	// Scaling by t/q.
+	dm := params.DecryptionModulus()
+	x.divRound(dm, r.q)
 	// Message as []*big.Int.
-	mb := VecSymMod(vsm, dm)
-	// // Message as []int64.
-	// m := make([]int64, len(mb))
-	// for i := 0; i < len(m); i++ {
-	// 	m[i] = mb[i].Int64()
-	// }
-	return mb, nil
+	return NewPlaintext(VecSymMod(x.Coeffs, dm), ct.Codec, params), nil
+}
+
+// NoiseBudget returns the number of bits of noise that a ciphertext can still
+// absorb before its decryption fails. It computes the invariant noise
+// [t * (c0 + c1*s + ... + ck*s^k)]_q, which is t times the noise plus a
+// multiple of q, and compares its infinity norm with q/2. The budget is zero
+// when the ciphertext no longer decrypts correctly.
+func (dec *Decryptor) NoiseBudget(ct *Ciphertext) (float64, error) {
+	if dec == nil {
+		return 0, ErrKeyIsNotValid
+	}
+	// Parameters.
+	p := dec.sk.Params
+	if err := validateCiphertext(ct, p); err != nil {
+		return 0, err
+	}
+	r := dec.ring
+	sk := r.Poly(dec.sk.Value)
+	// c0 + c1*s + ... + ck*s^k with Horner's rule.
+	d := ct.Degree()
+	x := r.NewPoly().CopyFrom(r.Poly(ct.Value[d]))
+	for i := d - 1; i >= 0; i-- {
+		if _, err := x.Mul(x, sk); err != nil {
+			return 0, err
+		}
+		x.Add(x, r.Poly(ct.Value[i])).SymModInPlace()
+	}
+	// Invariant noise.
+	x.MulScalar(x, p.DecryptionModulus()).SymModInPlace()
+	norm := new(big.Int)
+	for i := 0; i < len(x.Coeffs); i++ {
+		if x.Coeffs[i].CmpAbs(norm) > 0 {
+			norm.Abs(x.Coeffs[i])
+		}
+	}
+	if norm.Sign() == 0 {
+		return params.Log2(r.q) - 1, nil
+	}
+	return math.Max(params.Log2(r.q)-params.Log2(norm)-1, 0), nil
 }
@@ -1,6 +1,7 @@
 package scheme
 
 import (
+	"math"
 	"math/big"
 	"testing"
 
@@ -44,7 +45,7 @@ func TestBFVEnc(t *testing.T) {
 		t.Error(err)
 	}
 	// SIM2D encode message 0 (12345.678).
-	m0pb := sc.Enc(params.M0)
+	m0pb := NewPlaintext(sc.Enc(params.M0), CodecSIM2D, p)
 	// Encrypted message 0 coded.
 	ecm0, err := cip.Enc(m0pb)
 	if err != nil {
@@ -54,9 +55,9 @@ func TestBFVEnc(t *testing.T) {
 	c0p := [][]int64{{-3946857853, -2345757187, -3109794709, 3597287897, 3028009461, 4583068216, -793865802, -2493685872, -4356875925, 1446151915, 155195555, -836686371, 2066582773, 4556910428, -2021827196, 4761980565, 2917531420, -2913214043, 3106945542, -1805764275, 2186795682, 2186917851, -35413790, -3547097327, -2678934769, 370357284, -3991359467, -2430971626, -454123784, 3426324942, -4362094484, -690920067},
 		{3195929502, -2081665433, -178354177, -2509028365, -983465806, -1897902712, -1876620253, 2041701261, -4906960634, 642398707, -610816364, 3631039276, 2786954504, -636686207, -1293593148, 2004632060, -1556256737, -1792426624, 4406608444, -616705209, 2610504892, -1343850320, 1290341989, 3158043352, -3566746461, -3750192113, -4155437198, -1755722382, 1735554956, -2781807849, 2360617240, -3849070250}}
 	for i := 0; i < len(c0p); i++ {
-		for j := 0; j < len(ecm0[i]); j++ {
-			if c0pb := big.NewInt(c0p[i][j]); c0pb.Cmp(ecm0[i][j]) != 0 {
-				t.Errorf("expected value %s at position [%d][%d] but got %s", c0pb.String(), i, j, ecm0[i][j].String())
+		for j := 0; j < len(ecm0.Value[i]); j++ {
+			if c0pb := big.NewInt(c0p[i][j]); c0pb.Cmp(ecm0.Value[i][j]) != 0 {
+				t.Errorf("expected value %s at position [%d][%d] but got %s", c0pb.String(), i, j, ecm0.Value[i][j].String())
 				break
 			}
 		}
@@ -103,7 +104,7 @@ func TestHERatioEnc(t *testing.T) {
 		m0b[i] = big.NewInt(m0[i])
 	}
 	// Encrypted message 0.
-	c0, err := cip.Enc(m0b)
+	c0, err := cip.Enc(NewPlaintext(m0b, CodecLaurent, p))
 	if err != nil {
 		t.Error(err)
 	}
@@ -112,8 +113,8 @@ func TestHERatioEnc(t *testing.T) {
 		{-1556256741, -1792426624, 4406608444, -616705210, 2610504891, -1343850320, 1290341989, 3158043354, -3566746457, -3750192107, -4155437196, -1755722380, 1735554954, -2781807856, 2360617235, -3849070249, -3195929512, 2081665431, 178354185, 2509028358, 983465813, 1897902712, 1876620253, -2041701257, 4906960634, -642398707, 610816366, -3631039280, -2786954504, 636686208, 1293593149, -2004632067}}
 	for i := 0; i < len(c0p); i++ {
 		for j := 0; j < len(c0p[i]); j++ {
-			if c0pb := big.NewInt(c0p[i][j]); c0pb.Cmp(c0[i][j]) != 0 {
-				t.Errorf("expected value %s at position [%d][%d] but got %s", c0pb.String(), i, j, c0[i][j].String())
+			if c0pb := big.NewInt(c0p[i][j]); c0pb.Cmp(c0.Value[i][j]) != 0 {
+				t.Errorf("expected value %s at position [%d][%d] but got %s", c0pb.String(), i, j, c0.Value[i][j].String())
 				break
 			}
 		}
@@ -140,7 +141,7 @@ func TestHERatioEnc(t *testing.T) {
 		t.Error(err)
 	}
 	// Encrypted message 1 coded.
-	c1, err := cip.Enc(m1b)
+	c1, err := cip.Enc(NewPlaintext(m1b, CodecLaurent, p))
 	if err != nil {
 		t.Error(err)
 	}
@@ -149,8 +150,8 @@ func TestHERatioEnc(t *testing.T) {
 		{4264193866, -1527646385, 1813200464, -3602499427, 1093465074, -258723476, 3334380716, 2120724897, 4787031003, 1767301708, -3677492885, 3839699240, -3074468330, -1120360217, 889645300, -4461608467, -2355083155, 3243450666, 1401312351, -4770263490, -1978232958, -4054237786, 1084734439, -422038801, -4464537862, -3270933427, -1790685853, 2585691139, 2152982520, 3343571564, 464216747, -4541369780}}
 	for i := 0; i < len(c1p); i++ {
 		for j := 0; j < len(c1p[i]); j++ {
-			if c1pb := big.NewInt(c1p[i][j]); c1pb.Cmp(c1[i][j]) != 0 {
-				t.Errorf("expected value %s at position [%d][%d] but got %s", c1pb.String(), i, j, c1[i][j].String())
+			if c1pb := big.NewInt(c1p[i][j]); c1pb.Cmp(c1.Value[i][j]) != 0 {
+				t.Errorf("expected value %s at position [%d][%d] but got %s", c1pb.String(), i, j, c1.Value[i][j].String())
 				break
 			}
 		}
@@ -191,12 +192,12 @@ func TestBFVDec(t *testing.T) {
 		}
 	}
 	// Decrypted message 0.
-	cm0, err := cip.Dec(ecm0)
+	cm0, err := cip.Dec(NewCiphertext(ecm0, CodecSIM2D, 0, p))
 	if err != nil {
 		t.Error(err)
 	}
 	// SIM2D message 0 decoded.
-	m0, err := sc.Dec(cm0)
+	m0, err := sc.Dec(cm0.Value)
 	if err != nil {
 		t.Error(err)
 	}
@@ -240,15 +241,15 @@ func TestHERatioDec(t *testing.T) {
 		}
 	}
 	// Decrypted message 0.
-	cm0, err := cip.Dec(c0pb)
+	cm0, err := cip.Dec(NewCiphertext(c0pb, CodecLaurent, 0, p))
 	if err != nil {
 		t.Error(err)
 	}
 	// Check message 0 decrypted.
 	m0 := []int64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, -2, -2, -3, -4, -5, 4, 2, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	for i := 0; i < len(m0); i++ {
-		if m0[i] != cm0[i].Int64() {
-			t.Errorf("expected %d but got %d", m0[i], cm0[i])
+		if m0[i] != cm0.Value[i].Int64() {
+			t.Errorf("expected %d but got %d", m0[i], cm0.Value[i])
 		}
 	}
 
@@ -264,15 +265,259 @@ func TestHERatioDec(t *testing.T) {
 		}
 	}
 	// Decrypted message 1.
-	cm1, err := cip.Dec(c1pb)
+	cm1, err := cip.Dec(NewCiphertext(c1pb, CodecLaurent, 0, p))
 	if err != nil {
 		t.Error(err)
 	}
 	// Check message 1 decrypted.
 	m1 := []int64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, -3, 3, 1, -3, -5, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	for i := 0; i < len(m1); i++ {
-		if m1[i] != cm1[i].Int64() {
-			t.Errorf("expected %d but got %d", m1[i], cm1[i])
+		if m1[i] != cm1.Value[i].Int64() {
+			t.Errorf("expected %d but got %d", m1[i], cm1.Value[i])
+		}
+	}
+}
+
+// testPresets are the parameter presets of params/constants.go.
+var testPresets = []struct {
+	name string
+	pl   params.Literal
+}{
+	{"PLHERatio16", params.PLHERatio16},
+	{"PLHERatio512", params.PLHERatio512},
+	{"PLBFV32", params.PLBFV32},
+	{"PLBFV512", params.PLBFV512},
+	{"PLBFV1024", params.PLBFV1024},
+	{"PLBFV2048", params.PLBFV2048},
+}
+
+func TestNewCipher(t *testing.T) {
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := make([]*big.Int, p.Size())
+	for i := 0; i < p.Size(); i++ {
+		m[i] = big.NewInt(int64(i % 3))
+	}
+	pt := NewPlaintext(m, CodecNone, p)
+	// Case: a keychain with the public key only encrypts, and refuses to decrypt.
+	cip, err := NewCipher(&Keychain{Params: p, PK: kc.PK, O: kc.O})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := cip.Enc(pt)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := cip.Dec(ct); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	if _, err := cip.NoiseBudget(ct); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	if _, err := cip.EncSym(pt); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	// Case: a keychain with the secret key only decrypts, and refuses to encrypt.
+	cip, err = NewCipher(&Keychain{Params: p, SK: kc.SK})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cip.Enc(pt); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	if r, err := cip.Dec(ct); err != nil {
+		t.Error(err)
+	} else if !equalPolys(r.Value, m) {
+		t.Errorf("expected message %v but got %v", m, r.Value)
+	}
+	// Case: a keychain without keys or with a malformed key is not valid.
+	for _, k := range []*Keychain{
+		{Params: p, O: kc.O},
+		{Params: p, PK: kc.PK[:1], O: kc.O},
+		{Params: p, SK: kc.SK[1:]},
+	} {
+		if _, err := NewCipher(k); err != ErrKeyIsNotValid {
+			t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+		}
+	}
+}
+
+func TestNoiseBudget(t *testing.T) {
+	for _, ps := range testPresets {
+		p, cip, eval := newTestCipher(t, ps.pl)
+		// Raw messages, since the budget does not depend on the codec.
+		m0 := make([]*big.Int, p.Size())
+		m1 := make([]*big.Int, p.Size())
+		for i := 0; i < p.Size(); i++ {
+			m0[i] = big.NewInt(int64(i % 7))
+			m1[i] = big.NewInt(int64(i%5) - 2)
+		}
+		c0, err := cip.Enc(NewPlaintext(m0, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		c1, err := cip.Enc(NewPlaintext(m1, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		// Operations.
+		cs, err := eval.SAdd(c0, NewPlaintext(m1, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		ca, err := eval.Add(c0, c1)
+		if err != nil {
+			t.Error(err)
+		}
+		csm, err := eval.SMult(c0, big.NewInt(params.MS))
+		if err != nil {
+			t.Error(err)
+		}
+		cm, err := eval.Mult(c0, c1)
+		if err != nil {
+			t.Error(err)
+		}
+		b := make([]float64, 5)
+		for i, c := range []*Ciphertext{c0, cs, ca, csm, cm} {
+			if b[i], err = cip.NoiseBudget(c); err != nil {
+				t.Error(err)
+			}
+		}
+		t.Logf("%s: fresh %.1f, SAdd %.1f, Add %.1f, SMult %.1f, Mult %.1f bits", ps.name, b[0], b[1], b[2], b[3], b[4])
+		// Case: fresh ciphertexts have a positive budget.
+		if b[0] <= 0 {
+			t.Errorf("expected a positive noise budget for %s but got %f", ps.name, b[0])
+		}
+		// Case: the multiplication by 4 consumes 2 bits.
+		if b[0] > 2 && math.Abs(b[3]-(b[0]-2)) > 1e-9 {
+			t.Errorf("expected noise budget %f after scalar multiplication for %s but got %f", b[0]-2, ps.name, b[3])
+		}
+		// Case: the multiplication consumes the largest part of the budget.
+		if b[4] >= math.Min(b[1], b[2]) {
+			t.Errorf("expected noise budget smaller than %f after multiplication for %s but got %f", math.Min(b[1], b[2]), ps.name, b[4])
+		}
+	}
+}
+
+func TestNoiseBudgetExhausted(t *testing.T) {
+	// Parameters.
+	p, cip, _ := newTestCipher(t, params.PLBFV32)
+	q := p.CoefficientModulus()
+	// Case: a ciphertext with uniform components has no budget left.
+	c := make([][]*big.Int, 2)
+	for i := 0; i < len(c); i++ {
+		ci, err := new(oracle.Oracle).RandBigInt(new(big.Int).Neg(q), q, p.Size())
+		if err != nil {
+			t.Error(err)
+		}
+		c[i] = VecSymMod(ci, q)
+	}
+	b, err := cip.NoiseBudget(NewCiphertext(c, CodecNone, 0, p))
+	if err != nil {
+		t.Error(err)
+	}
+	if b >= 1 {
+		t.Errorf("expected a noise budget smaller than 1 bit but got %f", b)
+	}
+	// Case: degree 2 ciphertexts are measured with s^2.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	pl.CoefficientModulus.Sub(pl.CoefficientModulus, big.NewInt(189))
+	pb, cb, eb := newTestCipher(t, pl)
+	m := make([]*big.Int, pb.Size())
+	for i := 0; i < len(m); i++ {
+		m[i] = big.NewInt(1)
+	}
+	c0, err := cb.Enc(NewPlaintext(m, CodecNone, pb))
+	if err != nil {
+		t.Error(err)
+	}
+	d, err := eb.MultNoRelin(c0, c0)
+	if err != nil {
+		t.Error(err)
+	}
+	bd, err := cb.NoiseBudget(d)
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eb.Relinearize(d)
+	if err != nil {
+		t.Error(err)
+	}
+	br, err := cb.NoiseBudget(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	if bd <= 0 || br <= 0 {
+		t.Errorf("expected positive noise budgets before and after the relinearization but got %f and %f", bd, br)
+	}
+}
+
+func TestSymmetricEnc(t *testing.T) {
+	for _, ps := range testPresets {
+		p, cip, eval := newTestCipher(t, ps.pl)
+		// Raw messages.
+		m0 := make([]*big.Int, p.Size())
+		m1 := make([]*big.Int, p.Size())
+		for i := 0; i < p.Size(); i++ {
+			m0[i] = big.NewInt(int64(i % 7))
+			m1[i] = big.NewInt(int64(i%5) - 2)
+		}
+		// Secret-key and public-key encryptions.
+		cs, err := cip.EncSym(NewPlaintext(m0, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		cp, err := cip.Enc(NewPlaintext(m1, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		// Case: the decryption gives the message back.
+		ms, err := cip.Dec(cs)
+		if err != nil {
+			t.Error(err)
+		}
+		for i := 0; i < p.Size(); i++ {
+			if ms.Value[i].Cmp(m0[i]) != 0 {
+				t.Errorf("expected %s at position [%d] for %s but got %s", m0[i], i, ps.name, ms.Value[i])
+				break
+			}
+		}
+		// Case: the noise is lower than with the public key, and within its estimate.
+		bs, err := cip.NoiseBudget(cs)
+		if err != nil {
+			t.Error(err)
+		}
+		bp, err := cip.NoiseBudget(cp)
+		if err != nil {
+			t.Error(err)
+		}
+		if bs <= bp {
+			t.Errorf("expected a noise budget larger than %f for %s but got %f", bp, ps.name, bs)
+		}
+		if measured := p.NoiseCeiling() - bs; cs.Noise < measured || cs.Noise >= cp.Noise {
+			t.Errorf("expected a noise estimate in [%f, %f) for %s but got %f", measured, cp.Noise, ps.name, cs.Noise)
+		}
+		// Case: both encryptions can be combined by the evaluator.
+		ca, err := eval.Add(cs, cp)
+		if err != nil {
+			t.Error(err)
+		}
+		ma, err := cip.Dec(ca)
+		if err != nil {
+			t.Error(err)
+		}
+		for i := 0; i < p.Size(); i++ {
+			if e := new(big.Int).Add(m0[i], m1[i]); ma.Value[i].Cmp(e) != 0 {
+				t.Errorf("expected %s at position [%d] for %s but got %s", e, i, ps.name, ma.Value[i])
+				break
+			}
 		}
 	}
 }
@@ -0,0 +1,125 @@
+package scheme
+
+import (
+	"math/big"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// Codec identifies the encoding of the messages held by plaintexts and ciphertexts.
+type Codec int
+
+const (
+	CodecNone    Codec = iota // Raw polynomial (no codec).
+	CodecSIM2D                // SIM2D codec.
+	CodecLaurent              // Laurent codec.
+)
+
+// Plaintext is an encoded message tied to the parameters it was encoded with.
+type Plaintext struct {
+	Value       []*big.Int // Coefficients of the encoded message.
+	Fingerprint uint64     // Fingerprint of the parameters.
+	Scheme      int        // Scheme.
+	Codec       Codec      // Codec used to encode the message.
+}
+
+// NewPlaintext creates a plaintext from an encoded message.
+func NewPlaintext(m []*big.Int, c Codec, p *params.Params) *Plaintext {
+	return &Plaintext{Value: m, Fingerprint: p.Fingerprint(), Scheme: p.Scheme(), Codec: c}
+}
+
+// Ciphertext is an encrypted message tied to the parameters it was encrypted with.
+type Ciphertext struct {
+	Value       [][]*big.Int // Components of the ciphertext.
+	Fingerprint uint64       // Fingerprint of the parameters.
+	Scheme      int          // Scheme.
+	Codec       Codec        // Codec used to encode the message.
+	Noise       float64      // Estimated worst-case bound on the noise (log2).
+	AvgNoise    float64      // Estimated average-case bound on the noise (log2).
+
+	params *params.Params // Parameters, set by the constructors and by UnmarshalCiphertext.
+	width  int            // Width of the coefficients, set by UnmarshalBinary.
+}
+
+// NewCiphertext creates a ciphertext from its components, with the same worst-case
+// and average-case noise estimates.
+func NewCiphertext(c [][]*big.Int, codec Codec, noise float64, p *params.Params) *Ciphertext {
+	return newCiphertext(c, codec, params.Noise{Worst: noise, Avg: noise}, p)
+}
+
+// newCiphertext creates a ciphertext from its components and noise estimate.
+func newCiphertext(c [][]*big.Int, codec Codec, n params.Noise, p *params.Params) *Ciphertext {
+	return &Ciphertext{Value: c, Fingerprint: p.Fingerprint(), Scheme: p.Scheme(), Codec: codec, Noise: n.Worst, AvgNoise: n.Avg, params: p}
+}
+
+// Degree returns the degree of the ciphertext as a polynomial in the secret key,
+// i.e., its number of components minus one. Fresh ciphertexts have degree 1.
+func (ct *Ciphertext) Degree() int {
+	return len(ct.Value) - 1
+}
+
+// Copy returns a deep copy of the ciphertext.
+func (ct *Ciphertext) Copy() *Ciphertext {
+	c := make([][]*big.Int, len(ct.Value))
+	for i := 0; i < len(c); i++ {
+		c[i] = make([]*big.Int, len(ct.Value[i]))
+		for j := 0; j < len(c[i]); j++ {
+			c[i][j] = new(big.Int).Set(ct.Value[i][j])
+		}
+	}
+	return &Ciphertext{Value: c, Fingerprint: ct.Fingerprint, Scheme: ct.Scheme, Codec: ct.Codec, Noise: ct.Noise, AvgNoise: ct.AvgNoise, params: ct.params, width: ct.width}
+}
+
+// validatePlaintext checks if a plaintext belongs to the given parameters.
+func validatePlaintext(pt *Plaintext, p *params.Params) error {
+	if pt == nil {
+		return ErrPlaintextIsNil
+	}
+	if pt.Scheme != p.Scheme() {
+		return ErrSchemeMismatch
+	}
+	if pt.Fingerprint != p.Fingerprint() {
+		return ErrParametersMismatch
+	}
+	if len(pt.Value) != p.Size() {
+		return ErrPlaintextSizeIsNotValid
+	}
+	return nil
+}
+
+// validateCiphertext checks if a ciphertext belongs to the given parameters.
+func validateCiphertext(ct *Ciphertext, p *params.Params) error {
+	if ct == nil {
+		return ErrCiphertextIsNil
+	}
+	if ct.Scheme != p.Scheme() {
+		return ErrSchemeMismatch
+	}
+	if ct.Fingerprint != p.Fingerprint() {
+		return ErrParametersMismatch
+	}
+	if ct.Degree() < 1 {
+		return ErrCiphertextDegreeIsNotValid
+	}
+	for i := 0; i < len(ct.Value); i++ {
+		if len(ct.Value[i]) != p.Size() {
+			return ErrCiphertextSizeIsNotValid
+		}
+	}
+	return nil
+}
+
+// compatible checks if two operands can be combined, i.e., if they belong to
+// the given parameters and encode messages with the same codec.
+func compatible(ct0, ct1 *Ciphertext, p *params.Params) error {
+	if err := validateCiphertext(ct0, p); err != nil {
+		return err
+	}
+	if err := validateCiphertext(ct1, p); err != nil {
+		return err
+	}
+	if ct0.Codec != ct1.Codec {
+		return ErrCodecMismatch
+	}
+	return nil
+}
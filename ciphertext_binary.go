@@ -0,0 +1,243 @@
+package scheme
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// Wire format of ciphertexts. All the fields are big-endian.
+//
+//	magic       [4]byte  "HRCT"
+//	version     uint8    ciphertextVersion
+//	scheme      uint8    params.BFV or params.HERatio
+//	codec       uint8    Codec
+//	components  uint8    number of components (at least 2)
+//	fingerprint uint64   fingerprint of the parameters
+//	size        uint32   number of coefficients per component
+//	width       uint16   bits per coefficient
+//	noise       float64  worst-case noise estimate (log2)
+//	avgNoise    float64  average-case noise estimate (log2)
+//	coefficients         components*size values of width bits, packed
+//
+// The width is ceil(log2 q) bits, i.e., the bit length of q, which fixes the size of
+// the data for given parameters. Each coefficient is reduced modulo q to v in
+// [-floor(q/2), ceil(q/2)) and stored as v + 2^(width-1), whose sign is thus the
+// most significant bit, and the last byte is padded with zero bits.
+const (
+	ciphertextVersion    = 1
+	ciphertextHeaderSize = 4 + 4 + 8 + 4 + 2 + 8 + 8
+	maxCiphertextSize    = 1 << 16 // Largest Size, i.e., Factor*Degree.
+	maxCiphertextWidth   = 1 << 13 // Largest width of a coefficient, in bits.
+)
+
+// ciphertextMagic identifies encoded ciphertexts.
+var ciphertextMagic = []byte("HRCT")
+
+// MarshalBinary encodes the ciphertext in the versioned wire format. The ciphertext
+// must be created with its parameters, or decoded by UnmarshalCiphertext or
+// UnmarshalBinary. In the latter case, the coefficients are written back with the
+// width they were read with, so that the data is the same.
+func (ct *Ciphertext) MarshalBinary() ([]byte, error) {
+	if ct == nil {
+		return nil, ErrCiphertextIsNil
+	}
+	var n, w int
+	var q *big.Int
+	switch p := ct.params; {
+	case p != nil:
+		if err := validateCiphertext(ct, p); err != nil {
+			return nil, err
+		}
+		n = p.Size()
+		q = p.CoefficientModulus()
+		w = q.BitLen()
+	case ct.width != 0:
+		if len(ct.Value) < 2 {
+			return nil, ErrCiphertextDegreeIsNotValid
+		}
+		n, w = len(ct.Value[0]), ct.width
+		for i := 1; i < len(ct.Value); i++ {
+			if len(ct.Value[i]) != n {
+				return nil, ErrCiphertextSizeIsNotValid
+			}
+		}
+	default:
+		return nil, ErrParametersAreUnknown
+	}
+	if len(ct.Value) > math.MaxUint8 {
+		return nil, ErrCiphertextDegreeIsNotValid
+	}
+	if n > maxCiphertextSize || w > maxCiphertextWidth {
+		return nil, ErrCiphertextDataIsNotValid
+	}
+	// Header.
+	var buf bytes.Buffer
+	buf.Write(ciphertextMagic)
+	buf.Write([]byte{ciphertextVersion, byte(ct.Scheme), byte(ct.Codec), byte(len(ct.Value))})
+	binary.Write(&buf, binary.BigEndian, ct.Fingerprint)
+	binary.Write(&buf, binary.BigEndian, uint32(n))
+	binary.Write(&buf, binary.BigEndian, uint16(w))
+	binary.Write(&buf, binary.BigEndian, math.Float64bits(ct.Noise))
+	binary.Write(&buf, binary.BigEndian, math.Float64bits(ct.AvgNoise))
+	// Coefficients.
+	bw := &bitWriter{data: make([]byte, 0, packedSize(len(ct.Value), n, w))}
+	offset := new(big.Int).Lsh(big.NewInt(1), uint(w-1))
+	lo := new(big.Int).Neg(offset)
+	var half *big.Int
+	if q != nil {
+		half = new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1)
+	}
+	u := new(big.Int)
+	for i := 0; i < len(ct.Value); i++ {
+		for j := 0; j < n; j++ {
+			if q != nil {
+				// Symmetric representative, in [-floor(q/2), ceil(q/2)).
+				if u.Mod(ct.Value[i][j], q); u.Cmp(half) >= 0 {
+					u.Sub(u, q)
+				}
+			} else if u.Set(ct.Value[i][j]); u.Cmp(lo) < 0 || u.Cmp(offset) >= 0 {
+				// Without parameters, the coefficients must fit into the width.
+				return nil, ErrCiphertextDataIsNotValid
+			}
+			bw.write(u.Add(u, offset), w)
+		}
+	}
+	buf.Write(bw.data)
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a ciphertext encoded by MarshalBinary. It checks the
+// header and the length of the data before decoding the coefficients, and
+// returns ErrCiphertextDataIsNotValid for malformed or oversized data, or for
+// noise estimates that are NaN or +Inf (-Inf is params.NoNoise). Since the
+// coefficient modulus is not part of the data, UnmarshalCiphertext checks the
+// width and the coefficients against the parameters of the ciphertext.
+func (ct *Ciphertext) UnmarshalBinary(data []byte) error {
+	if len(data) < ciphertextHeaderSize || !bytes.Equal(data[:4], ciphertextMagic) {
+		return ErrCiphertextDataIsNotValid
+	}
+	if data[4] != ciphertextVersion {
+		return ErrCiphertextVersionIsNotValid
+	}
+	scheme, codec, c := int(data[5]), Codec(data[6]), int(data[7])
+	fingerprint := binary.BigEndian.Uint64(data[8:16])
+	n := int(binary.BigEndian.Uint32(data[16:20]))
+	w := int(binary.BigEndian.Uint16(data[20:22]))
+	noise := math.Float64frombits(binary.BigEndian.Uint64(data[22:30]))
+	avgNoise := math.Float64frombits(binary.BigEndian.Uint64(data[30:38]))
+	switch {
+	case scheme != params.BFV && scheme != params.HERatio:
+		return ErrCiphertextDataIsNotValid
+	case codec != CodecNone && codec != CodecSIM2D && codec != CodecLaurent:
+		return ErrCiphertextDataIsNotValid
+	case c < 2:
+		return ErrCiphertextDataIsNotValid
+	case n == 0 || n > maxCiphertextSize || n&(n-1) != 0:
+		return ErrCiphertextDataIsNotValid
+	case w == 0 || w > maxCiphertextWidth:
+		return ErrCiphertextDataIsNotValid
+	case len(data)-ciphertextHeaderSize != packedSize(c, n, w):
+		return ErrCiphertextDataIsNotValid
+	case math.IsNaN(noise) || math.IsInf(noise, 1) || math.IsNaN(avgNoise) || math.IsInf(avgNoise, 1):
+		return ErrCiphertextDataIsNotValid
+	}
+	// Coefficients.
+	br := &bitReader{data: data[ciphertextHeaderSize:]}
+	offset := new(big.Int).Lsh(big.NewInt(1), uint(w-1))
+	value := make([][]*big.Int, c)
+	for i := 0; i < c; i++ {
+		value[i] = make([]*big.Int, n)
+		for j := 0; j < n; j++ {
+			value[i][j] = br.read(w)
+			value[i][j].Sub(value[i][j], offset)
+		}
+	}
+	// The padding must be zero.
+	if br.pos < 8*len(br.data) && br.read(8*len(br.data)-br.pos).Sign() != 0 {
+		return ErrCiphertextDataIsNotValid
+	}
+	*ct = Ciphertext{Value: value, Fingerprint: fingerprint, Scheme: scheme, Codec: codec, Noise: noise, AvgNoise: avgNoise, width: w}
+	return nil
+}
+
+// UnmarshalCiphertext decodes a ciphertext encoded by MarshalBinary with the
+// parameters p. Besides the checks of UnmarshalBinary, the ciphertext must
+// match the parameters, and the width and coefficients must be the ones of a
+// ciphertext reduced modulo q, i.e., in [-floor(q/2), ceil(q/2)).
+func UnmarshalCiphertext(data []byte, p *params.Params) (*Ciphertext, error) {
+	ct := new(Ciphertext)
+	if err := ct.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	q := p.CoefficientModulus()
+	if int(binary.BigEndian.Uint16(data[20:22])) != q.BitLen() {
+		return nil, ErrCiphertextDataIsNotValid
+	}
+	if err := validateReduced(ct, q); err != nil {
+		return nil, err
+	}
+	ct.params, ct.width = p, 0
+	return ct, nil
+}
+
+// validateReduced checks that the coefficients of a decoded ciphertext are reduced
+// modulo q, i.e., in [-floor(q/2), ceil(q/2)).
+func validateReduced(ct *Ciphertext, q *big.Int) error {
+	hi := new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1)
+	lo := new(big.Int).Sub(hi, q)
+	for i := 0; i < len(ct.Value); i++ {
+		for j := 0; j < len(ct.Value[i]); j++ {
+			if v := ct.Value[i][j]; v.Cmp(lo) < 0 || v.Cmp(hi) >= 0 {
+				return ErrCiphertextDataIsNotValid
+			}
+		}
+	}
+	return nil
+}
+
+// packedSize returns the number of bytes of c components of n coefficients of w bits.
+func packedSize(c, n, w int) int {
+	return (c*n*w + 7) / 8
+}
+
+// bitWriter appends values of a given number of bits to a slice of bytes.
+type bitWriter struct {
+	data []byte
+	pos  int // Number of bits written.
+}
+
+// write appends the w least significant bits of the non-negative value v.
+func (bw *bitWriter) write(v *big.Int, w int) {
+	for i := w - 1; i >= 0; i-- {
+		if bw.pos%8 == 0 {
+			bw.data = append(bw.data, 0)
+		}
+		bw.data[len(bw.data)-1] |= byte(v.Bit(i)) << (7 - bw.pos%8)
+		bw.pos++
+	}
+}
+
+// bitReader reads values of a given number of bits from a slice of bytes.
+type bitReader struct {
+	data []byte
+	pos  int // Number of bits read.
+}
+
+// read returns the next w bits as a non-negative value.
+func (br *bitReader) read(w int) *big.Int {
+	v := new(big.Int)
+	for i := w - 1; i >= 0; i-- {
+		if br.data[br.pos/8]>>(7-br.pos%8)&1 == 1 {
+			v.SetBit(v, i, 1)
+		}
+		br.pos++
+	}
+	return v
+}
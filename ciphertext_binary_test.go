@@ -0,0 +1,243 @@
+package scheme
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+func TestCiphertextBinary(t *testing.T) {
+	for _, ps := range testPresets {
+		p, cip, _ := newTestCipher(t, ps.pl)
+		// Raw message.
+		m := make([]*big.Int, p.Size())
+		for i := 0; i < p.Size(); i++ {
+			m[i] = big.NewInt(int64(i%7) - 3)
+		}
+		ct, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		// Encode.
+		data, err := ct.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		// Case: coefficients are packed in ceil(log2 q) bits.
+		w := p.CoefficientModulus().BitLen()
+		if ew := ciphertextHeaderSize + packedSize(2, p.Size(), w); len(data) != ew {
+			t.Errorf("expected %d bytes for %s but got %d", ew, ps.name, len(data))
+		}
+		// Case: the decoded ciphertext is identical and decrypts to the message.
+		dct, err := UnmarshalCiphertext(data, p)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if dct.Fingerprint != ct.Fingerprint || dct.Scheme != ct.Scheme || dct.Codec != ct.Codec || dct.Noise != ct.Noise || dct.AvgNoise != ct.AvgNoise {
+			t.Errorf("expected the header of the ciphertext to be restored for %s", ps.name)
+		}
+		for i := 0; i < len(ct.Value); i++ {
+			if !equalPolys(dct.Value[i], ct.Value[i]) {
+				t.Errorf("expected component [%d] of the ciphertext to be restored for %s", i, ps.name)
+			}
+		}
+		mr, err := cip.Dec(dct)
+		if err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(mr.Value, m) {
+			t.Errorf("expected the decoded ciphertext to decrypt to the message for %s", ps.name)
+		}
+	}
+}
+
+func TestCiphertextBinaryComponents(t *testing.T) {
+	// Case: ciphertexts with three components and the extremes of the coefficients.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	q := p.CoefficientModulus()
+	hi := new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1)
+	lo := new(big.Int).Sub(hi, q)
+	c := make([][]*big.Int, 3)
+	for i := 0; i < len(c); i++ {
+		c[i] = make([]*big.Int, p.Size())
+		for j := 0; j < p.Size(); j++ {
+			c[i][j] = big.NewInt(0)
+		}
+	}
+	zero, err := NewCiphertext(c, CodecSIM2D, 1, p).MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	c[2][0].Set(lo)
+	c[2][1].Sub(hi, big.NewInt(1))
+	// Coefficients that are not reduced modulo q are encoded by their representative.
+	c[2][2].Set(q)
+	c[2][3].Sub(lo, big.NewInt(1))
+	ct := NewCiphertext(c, CodecSIM2D, 1, p)
+	data, err := ct.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: the width is the one of q, whatever the coefficients.
+	if w := binary.BigEndian.Uint16(data[20:22]); int(w) != q.BitLen() {
+		t.Errorf("expected width %d but got %d", q.BitLen(), w)
+	}
+	if len(data) != len(zero) {
+		t.Errorf("expected %d bytes but got %d", len(zero), len(data))
+	}
+	dct, err := UnmarshalCiphertext(data, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c[2][2].SetInt64(0)
+	c[2][3].Sub(hi, big.NewInt(1))
+	for i := 0; i < len(c); i++ {
+		if !equalPolys(dct.Value[i], c[i]) {
+			t.Errorf("expected component [%d] of the ciphertext to be restored", i)
+		}
+	}
+	// Case: the decoded ciphertext can be encoded again.
+	if d, err := dct.MarshalBinary(); err != nil || string(d) != string(data) {
+		t.Errorf("expected the decoded ciphertext to be encoded again but got %v", err)
+	}
+	// Case: ciphertexts that cannot be encoded.
+	if _, err := NewCiphertext(c[:1], CodecSIM2D, 1, p).MarshalBinary(); err != ErrCiphertextDegreeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDegreeIsNotValid, err)
+	}
+	if _, err := NewCiphertext([][]*big.Int{c[0], c[1][:3]}, CodecSIM2D, 1, p).MarshalBinary(); err != ErrCiphertextSizeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextSizeIsNotValid, err)
+	}
+	// Case: a ciphertext decoded without its parameters is encoded again as it was read.
+	uct := new(Ciphertext)
+	if err := uct.UnmarshalBinary(data); err != nil {
+		t.Error(err)
+	}
+	if d, err := uct.MarshalBinary(); err != nil || string(d) != string(data) {
+		t.Errorf("expected the decoded ciphertext to be encoded again but got %v", err)
+	}
+	if d, err := uct.Copy().MarshalBinary(); err != nil || string(d) != string(data) {
+		t.Errorf("expected the copy of the decoded ciphertext to be encoded again but got %v", err)
+	}
+	// Case: its coefficients must still fit into the width.
+	uct.Value[0][0].Lsh(big.NewInt(1), uint(p.CoefficientModulus().BitLen()-1))
+	if _, err := uct.MarshalBinary(); err != ErrCiphertextDataIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDataIsNotValid, err)
+	}
+	// Case: a ciphertext without parameters or width.
+	if _, err := (&Ciphertext{Value: c}).MarshalBinary(); err != ErrParametersAreUnknown {
+		t.Errorf("expected error %s but got %v", ErrParametersAreUnknown, err)
+	}
+}
+
+// packCiphertext encodes the components c with the header of data and the width w,
+// without reducing the coefficients.
+func packCiphertext(data []byte, c [][]*big.Int, w int) []byte {
+	d := append([]byte{}, data[:ciphertextHeaderSize]...)
+	d[7] = byte(len(c))
+	binary.BigEndian.PutUint32(d[16:20], uint32(len(c[0])))
+	binary.BigEndian.PutUint16(d[20:22], uint16(w))
+	bw := new(bitWriter)
+	offset := new(big.Int).Lsh(big.NewInt(1), uint(w-1))
+	for i := 0; i < len(c); i++ {
+		for j := 0; j < len(c[i]); j++ {
+			bw.write(new(big.Int).Add(c[i][j], offset), w)
+		}
+	}
+	return append(d, bw.data...)
+}
+
+func TestCiphertextBinaryErrors(t *testing.T) {
+	p, cip, _ := newTestCipher(t, params.PLBFV32)
+	m := make([]*big.Int, p.Size())
+	for i := 0; i < p.Size(); i++ {
+		m[i] = big.NewInt(int64(i % 3))
+	}
+	ct, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+	if err != nil {
+		t.Error(err)
+	}
+	data, err := ct.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	// modify returns a copy of the data changed by f.
+	modify := func(f func(d []byte) []byte) []byte {
+		d := append([]byte{}, data...)
+		return f(d)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, ErrCiphertextDataIsNotValid},
+		{"truncated header", data[:ciphertextHeaderSize-1], ErrCiphertextDataIsNotValid},
+		{"truncated coefficients", data[:len(data)-1], ErrCiphertextDataIsNotValid},
+		{"trailing data", modify(func(d []byte) []byte { return append(d, 0) }), ErrCiphertextDataIsNotValid},
+		{"magic", modify(func(d []byte) []byte { d[0] = 'X'; return d }), ErrCiphertextDataIsNotValid},
+		{"version", modify(func(d []byte) []byte { d[4] = 2; return d }), ErrCiphertextVersionIsNotValid},
+		{"scheme", modify(func(d []byte) []byte { d[5] = 9; return d }), ErrCiphertextDataIsNotValid},
+		{"codec", modify(func(d []byte) []byte { d[6] = 9; return d }), ErrCiphertextDataIsNotValid},
+		{"one component", modify(func(d []byte) []byte { d[7] = 1; return d }), ErrCiphertextDataIsNotValid},
+		{"size", modify(func(d []byte) []byte { binary.BigEndian.PutUint32(d[16:20], 1<<30); return d }), ErrCiphertextDataIsNotValid},
+		{"size not a power of two", modify(func(d []byte) []byte { binary.BigEndian.PutUint32(d[16:20], 31); return d }), ErrCiphertextDataIsNotValid},
+		{"width", modify(func(d []byte) []byte { binary.BigEndian.PutUint16(d[20:22], 0xffff); return d }), ErrCiphertextDataIsNotValid},
+		{"zero width", modify(func(d []byte) []byte { binary.BigEndian.PutUint16(d[20:22], 0); return d }), ErrCiphertextDataIsNotValid},
+		{"NaN noise", modify(func(d []byte) []byte { binary.BigEndian.PutUint64(d[22:30], math.Float64bits(math.NaN())); return d }), ErrCiphertextDataIsNotValid},
+		{"infinite average noise", modify(func(d []byte) []byte { binary.BigEndian.PutUint64(d[30:38], math.Float64bits(math.Inf(1))); return d }), ErrCiphertextDataIsNotValid},
+	}
+	for _, tt := range tests {
+		if err := new(Ciphertext).UnmarshalBinary(tt.data); err != tt.err {
+			t.Errorf("expected error %v for %s but got %v", tt.err, tt.name, err)
+		}
+	}
+	// Case: -Inf is the estimate of a ciphertext without noise.
+	nn := ct.Copy()
+	nn.Noise, nn.AvgNoise = params.NoNoise.Worst, params.NoNoise.Avg
+	if d, err := nn.MarshalBinary(); err != nil {
+		t.Error(err)
+	} else if dn, err := UnmarshalCiphertext(d, p); err != nil || !math.IsInf(dn.Noise, -1) {
+		t.Errorf("expected a ciphertext without noise but got %v", err)
+	}
+	// Case: nonzero padding bits, after 4 coefficients of 3 bits.
+	c := [][]*big.Int{{big.NewInt(3), big.NewInt(0)}, {big.NewInt(-2), big.NewInt(1)}}
+	d := packCiphertext(data, c, 3)
+	d[len(d)-1] |= 1
+	if err := new(Ciphertext).UnmarshalBinary(d); err != ErrCiphertextDataIsNotValid {
+		t.Errorf("expected error %v for padding but got %v", ErrCiphertextDataIsNotValid, err)
+	}
+	// Case: data that does not match the parameters.
+	q := p.CoefficientModulus()
+	hi := new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1)
+	out := ct.Copy()
+	out.Value[1][0].Set(hi)
+	p2048, err := params.New(params.PLBFV2048)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, tt := range []struct {
+		name string
+		data []byte
+		p    *params.Params
+		err  error
+	}{
+		{"parameters", data, p2048, ErrParametersMismatch},
+		{"width", packCiphertext(data, ct.Value, q.BitLen()+1), p, ErrCiphertextDataIsNotValid},
+		{"coefficient", packCiphertext(data, out.Value, q.BitLen()), p, ErrCiphertextDataIsNotValid},
+	} {
+		if _, err := UnmarshalCiphertext(tt.data, tt.p); err != tt.err {
+			t.Errorf("expected error %v for %s but got %v", tt.err, tt.name, err)
+		}
+	}
+	// Case: the same data is valid with the modulus of the parameters.
+	if _, err := UnmarshalCiphertext(packCiphertext(data, ct.Value, q.BitLen()), p); err != nil {
+		t.Error(err)
+	}
+}
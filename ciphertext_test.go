@@ -0,0 +1,168 @@
+package scheme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
+)
+
+// newTestCipher creates a keychain, cipher and evaluator for the given parameters.
+func newTestCipher(t *testing.T, pl params.Literal) (*params.Params, *Cipher, *Evaluator) {
+	p, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	return p, cip, NewEvaluator(kc)
+}
+
+func TestCiphertextCompatibility(t *testing.T) {
+	// Parameters.
+	pb, cb, eb := newTestCipher(t, params.PLBFV32)
+	ph, ch, _ := newTestCipher(t, params.PLHERatio16)
+	// Codecs.
+	sc, err := sim2d.New(pb)
+	if err != nil {
+		t.Error(err)
+	}
+	lc := laurent.New(ph)
+	// Ciphertexts.
+	c0, err := cb.Enc(NewPlaintext(sc.Enc(params.M0), CodecSIM2D, pb))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := ch.Enc(NewPlaintext(lc.Enc(params.M1), CodecLaurent, ph))
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Case: fresh ciphertexts carry their parameters.
+	if c0.Degree() != 1 || c0.Scheme != params.BFV || c0.Codec != CodecSIM2D || c0.Fingerprint != pb.Fingerprint() {
+		t.Errorf("fresh ciphertext does not carry its parameters")
+	}
+	if c0.Noise <= 0 {
+		t.Errorf("expected a positive noise estimate but got %f", c0.Noise)
+	}
+
+	// Case: BFV and HERatio ciphertexts cannot be added.
+	if _, err := eb.Add(c0, c1); err != ErrSchemeMismatch {
+		t.Errorf("expected error %s but got %v", ErrSchemeMismatch, err)
+	}
+
+	// Case: ciphertexts from different parameters of the same scheme.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = new(big.Int).Add(pl.CoefficientModulus, big.NewInt(2))
+	po, co, _ := newTestCipher(t, pl)
+	c2, err := co.Enc(NewPlaintext(sc.Enc(params.M1), CodecSIM2D, po))
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := eb.Mult(c0, c2); err != ErrParametersMismatch {
+		t.Errorf("expected error %s but got %v", ErrParametersMismatch, err)
+	}
+
+	// Case: ciphertexts with different codecs.
+	c3, err := cb.Enc(NewPlaintext(sc.Enc(params.M1), CodecNone, pb))
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := eb.Add(c0, c3); err != ErrCodecMismatch {
+		t.Errorf("expected error %s but got %v", ErrCodecMismatch, err)
+	}
+	if _, err := eb.SAdd(c0, NewPlaintext(sc.Enc(params.AS), CodecNone, pb)); err != ErrCodecMismatch {
+		t.Errorf("expected error %s but got %v", ErrCodecMismatch, err)
+	}
+
+	// Case: ciphertexts of degree 2 cannot be multiplied nor decrypted, but can be added.
+	c4 := NewCiphertext(append(c0.Value, c0.Value[1]), CodecSIM2D, c0.Noise, pb)
+	if _, err := eb.Mult(c0, c4); err != ErrCiphertextDegreeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDegreeIsNotValid, err)
+	}
+	if _, err := cb.Dec(c4); err != ErrCiphertextDegreeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDegreeIsNotValid, err)
+	}
+	if ca, err := eb.Add(c0, c4); err != nil || ca.Degree() != 2 {
+		t.Errorf("expected a ciphertext of degree 2 but got %v", err)
+	}
+	if _, err := eb.Relinearize(c0); err != ErrCiphertextDegreeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDegreeIsNotValid, err)
+	}
+
+	// Case: malformed ciphertexts.
+	c5 := NewCiphertext([][]*big.Int{c0.Value[0][1:], c0.Value[1]}, CodecSIM2D, c0.Noise, pb)
+	if _, err := eb.SMult(c5, big.NewInt(params.MS)); err != ErrCiphertextSizeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextSizeIsNotValid, err)
+	}
+	c6 := NewCiphertext(c0.Value[:1], CodecSIM2D, c0.Noise, pb)
+	if _, err := eb.Add(c6, c6); err != ErrCiphertextDegreeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCiphertextDegreeIsNotValid, err)
+	}
+	if _, err := eb.Add(nil, c0); err != ErrCiphertextIsNil {
+		t.Errorf("expected error %s but got %v", ErrCiphertextIsNil, err)
+	}
+	if _, err := cb.Enc(nil); err != ErrPlaintextIsNil {
+		t.Errorf("expected error %s but got %v", ErrPlaintextIsNil, err)
+	}
+	if _, err := cb.Enc(NewPlaintext(sc.Enc(params.M0)[1:], CodecSIM2D, pb)); err != ErrPlaintextSizeIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrPlaintextSizeIsNotValid, err)
+	}
+}
+
+func TestCiphertextNoiseEstimate(t *testing.T) {
+	// Parameters.
+	p, cip, eval := newTestCipher(t, params.PLBFV32)
+	sc, err := sim2d.New(p)
+	if err != nil {
+		t.Error(err)
+	}
+	// Ciphertexts.
+	c0, err := cip.Enc(NewPlaintext(sc.Enc(params.M0), CodecSIM2D, p))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(NewPlaintext(sc.Enc(params.M1), CodecSIM2D, p))
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: the estimate grows with every operation.
+	ca, err := eval.Add(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	if ca.Noise <= c0.Noise {
+		t.Errorf("expected noise estimate greater than %f after addition but got %f", c0.Noise, ca.Noise)
+	}
+	cs, err := eval.SMult(c0, big.NewInt(params.MS))
+	if err != nil {
+		t.Error(err)
+	}
+	if cs.Noise != c0.Noise+2 {
+		t.Errorf("expected noise estimate %f after scalar multiplication but got %f", c0.Noise+2, cs.Noise)
+	}
+	cm, err := eval.Mult(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	if cm.Noise <= ca.Noise {
+		t.Errorf("expected noise estimate greater than %f after multiplication but got %f", ca.Noise, cm.Noise)
+	}
+	// Case: decryption keeps the codec.
+	pt, err := cip.Dec(cm)
+	if err != nil {
+		t.Error(err)
+	}
+	if pt.Codec != CodecSIM2D || pt.Fingerprint != p.Fingerprint() {
+		t.Errorf("decrypted plaintext does not carry its parameters")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Command heratio-params suggests parameters for a workload given by its
+multiplicative depth, the number of integer and fractional digits of the
+messages, and the security level.
+
+Usage:
+
+	heratio-params -scheme heratio -depth 2 -int 3 -frac 2 -security 128
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+func main() {
+	scheme := flag.String("scheme", "heratio", "scheme, bfv (SIM2D codec) or heratio (Laurent codec)")
+	depth := flag.Int("depth", 1, "multiplicative depth")
+	id := flag.Int("int", 1, "number of integer digits of the messages")
+	fd := flag.Int("frac", 0, "number of fractional digits of the messages")
+	security := flag.Int("security", params.Security128, "security level in bits (128, 192 or 256), or 0 for none")
+	base := flag.Int64("base", params.ExpansionBase, "expansion base of the codec")
+	flag.Parse()
+
+	req := params.Request{
+		Depth:            *depth,
+		IntegerDigits:    *id,
+		FractionalDigits: *fd,
+		Security:         *security,
+		ExpansionBase:    *base,
+	}
+	switch *scheme {
+	case "bfv":
+		req.Scheme = params.BFV
+	case "heratio":
+		req.Scheme = params.HERatio
+	default:
+		fmt.Fprintln(os.Stderr, params.ErrSchemeIsNotValid)
+		os.Exit(2)
+	}
+	s, err := params.Suggest(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	l := s.Literal
+	fmt.Printf("Degree:                       %d\n", l.Degree)
+	fmt.Printf("ExpansionBase:                %d\n", l.ExpansionBase)
+	fmt.Printf("CoefficientModulus:           %s\n", l.CoefficientModulus)
+	fmt.Printf("DecryptionModulus:            %s\n", l.DecryptionModulus)
+	fmt.Printf("RelinearizationExpansionBase: %s\n", l.RelinearizationExpansionBase)
+	fmt.Printf("StandardDeviation:            %g\n", l.StandardDeviation)
+	fmt.Printf("Bound:                        %d\n", l.Bound)
+	fmt.Printf("Factor:                       %d\n", l.Factor)
+	fmt.Printf("Scheme:                       %d\n", l.Scheme)
+	fmt.Println()
+	fmt.Println(s)
+}
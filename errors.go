@@ -3,5 +3,35 @@ package scheme
 import "errors"
 
 var (
-	ErrSchemeIsNotValid = errors.New("scheme is not valid")
+	ErrSchemeIsNotValid             = errors.New("scheme is not valid")
+	ErrModulusIsNotNTTFriendly      = errors.New("coefficient modulus is not an NTT-friendly prime")
+	ErrCiphertextIsNil              = errors.New("ciphertext cannot be nil")
+	ErrPlaintextIsNil               = errors.New("plaintext cannot be nil")
+	ErrParametersMismatch           = errors.New("operands were created with different parameters")
+	ErrSchemeMismatch               = errors.New("operands belong to different schemes")
+	ErrCodecMismatch                = errors.New("operands were encoded with different codecs")
+	ErrCiphertextDegreeIsNotValid   = errors.New("ciphertext degree is not valid for the operation")
+	ErrCiphertextSizeIsNotValid     = errors.New("ciphertext components should have the size of the parameters")
+	ErrPlaintextSizeIsNotValid      = errors.New("plaintext should have the size of the parameters")
+	ErrEvaluationKeyIsNotValid      = errors.New("evaluation key should have one pair of components per digit of the expansion")
+	ErrExponentIsNotValid           = errors.New("exponent should be a positive integer")
+	ErrNoiseBudgetExceeded          = errors.New("noise budget cannot support the multiplicative depth of the operation")
+	ErrCodecIsNotValid              = errors.New("codec of the ciphertext cannot encode real numbers")
+	ErrPolynomialIsNotValid         = errors.New("polynomial should have at least one coefficient")
+	ErrInnerProductLengthIsNotValid = errors.New("operands of the inner product should have the same nonzero length")
+	ErrKeyIsNotValid                = errors.New("key is missing or does not match its parameters")
+	ErrParametersAreUnknown         = errors.New("ciphertext was neither created with its parameters nor decoded")
+	ErrCiphertextDataIsNotValid     = errors.New("ciphertext data is malformed or exceeds the limits of the format")
+	ErrCiphertextVersionIsNotValid  = errors.New("ciphertext data has an unsupported version")
+	ErrKeyFileIsNotValid            = errors.New("key file is malformed")
+	ErrKeyFileVersionIsNotValid     = errors.New("key file has an unsupported version")
+	ErrKeyFileChecksumMismatch      = errors.New("key file checksum does not match its content")
+	ErrKeyNotFound                  = errors.New("key file was not found")
+	ErrKeyExists                    = errors.New("key file already exists")
+	ErrKeyStoreIsReadOnly           = errors.New("key store is read-only")
+	ErrKeyNameIsNotValid            = errors.New("key file name is not valid")
+	ErrPasswordIsEmpty              = errors.New("password cannot be empty")
+	ErrPasswordIsWrong              = errors.New("password is wrong or the exported secret key was modified")
+	ErrSealedKeyIsNotValid          = errors.New("exported secret key is malformed")
+	ErrArmorIsNotValid              = errors.New("armored text should hold a single block of the expected type")
 )
@@ -3,167 +3,605 @@ package scheme
 import (
 	"math/big"
 
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
 	"github.com/Algemetric/HERatio/Implementation/Golang/utils"
 )
 
+// NoiseCheck selects the noise estimate that the evaluator compares with the
+// decryption threshold before each operation.
+type NoiseCheck int
+
+const (
+	NoiseCheckNone    NoiseCheck = iota // Operations are never refused.
+	NoiseCheckAverage                   // Refuse operations whose average-case estimate exceeds the threshold.
+	NoiseCheckWorst                     // Refuse operations whose worst-case estimate exceeds the threshold.
+)
+
 // Evaluator has the functions that execute the mathematical operations.
 type Evaluator struct {
-	keychain *Keychain
+	params  *params.Params // Parameters.
+	ek      [][][]*big.Int // Evaluation key, which is only needed to relinearize.
+	ring    *Ring
+	check   NoiseCheck // Noise estimate checked before each operation.
+	ceiling float64    // Largest noise for which the decryption is correct (log2).
 }
 
 // NewEvaluator creates a new Evaluator.
 func NewEvaluator(kc *Keychain) *Evaluator {
+	return NewEvaluatorFromKey(kc.EvaluationKey())
+}
+
+// NewEvaluatorFromKey creates an Evaluator from an evaluation key alone, so that
+// the server that computes on ciphertexts needs neither the secret nor the public key.
+func NewEvaluatorFromKey(ek *EvaluationKey) *Evaluator {
 	// New structure.
 	e := new(Evaluator)
-	// Keychain.
-	e.keychain = kc
+	// Parameters and evaluation key.
+	e.params, e.ek = ek.Params, ek.Value
+	// Ring context.
+	e.ring = NewRing(ek.Params)
+	// Decryption threshold.
+	e.ceiling = ek.Params.NoiseCeiling()
 	return e
 }
 
-// SAdd executes the addition of a ciphertext and a scalar.
-func (e *Evaluator) SAdd(c [][]*big.Int, s []*big.Int) ([][]*big.Int, error) {
-	p := e.keychain.Params
-	// Calculate delta.
-	d := Delta(p)
-	// Calculate addition.
-	r := make([][]*big.Int, 2)
-	r[0] = make([]*big.Int, p.Size())
-	for i := 0; i < len(r[0]); i++ {
-		r[0][i] = big.NewInt(0)
-		r[0][i].Add(r[0][i], d)
-		r[0][i].Mul(r[0][i], s[i])
-		r[0][i].Add(r[0][i], c[0][i])
-	}
-	r[1] = c[1]
-	return r, nil
-}
-
-// Add executes the addition of two ciphertexts.
-func (e *Evaluator) Add(c0, c1 [][]*big.Int) [][]*big.Int {
-	p := e.keychain.Params
-	var c [][]*big.Int
-	c = append(c, SumZip(c0[0], c1[0], p))
-	c = append(c, SumZip(c0[1], c1[1], p))
-	// Coefficient modulus.
-	cm := big.NewInt(p.CoefficientModulus())
-	var s [][]*big.Int
+// SetNoiseCheck makes the evaluator refuse the operations whose noise estimate of
+// the result exceeds the decryption threshold, with ErrNoiseBudgetExceeded.
+func (e *Evaluator) SetNoiseCheck(c NoiseCheck) {
+	e.check = c
+}
+
+// checkNoise returns ErrNoiseBudgetExceeded if the noise estimate selected by the
+// noise check of the evaluator exceeds the decryption threshold.
+func (e *Evaluator) checkNoise(n params.Noise) error {
+	return e.checkNoiseWith(e.check, n)
+}
+
+// checkNoiseWith returns ErrNoiseBudgetExceeded if the noise estimate selected by
+// the noise check c exceeds the decryption threshold.
+func (e *Evaluator) checkNoiseWith(c NoiseCheck, n params.Noise) error {
+	switch {
+	case c == NoiseCheckWorst && n.Worst >= e.ceiling:
+		return ErrNoiseBudgetExceeded
+	case c == NoiseCheckAverage && n.Avg >= e.ceiling:
+		return ErrNoiseBudgetExceeded
+	}
+	return nil
+}
+
+// SAdd executes the addition of a ciphertext and an encoded scalar.
+func (e *Evaluator) SAdd(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	if err := e.validateAddPlain(ct, pt); err != nil {
+		return nil, err
+	}
+	c := ct.Copy()
+	e.addPlain(c, pt, Delta(e.params))
+	return c, nil
+}
+
+// SSub executes the subtraction of an encoded scalar from a ciphertext.
+func (e *Evaluator) SSub(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	if err := e.validateAddPlain(ct, pt); err != nil {
+		return nil, err
+	}
+	c := ct.Copy()
+	e.addPlain(c, pt, new(big.Int).Neg(Delta(e.params)))
+	return c, nil
+}
+
+// SSubInPlace subtracts an encoded scalar from a ciphertext, which holds the result.
+func (e *Evaluator) SSubInPlace(ct *Ciphertext, pt *Plaintext) error {
+	if err := e.validateAddPlain(ct, pt); err != nil {
+		return err
+	}
+	e.addPlain(ct, pt, new(big.Int).Neg(Delta(e.params)))
+	return nil
+}
+
+// validatePlain checks if a ciphertext and a plaintext can be combined.
+func (e *Evaluator) validatePlain(ct *Ciphertext, pt *Plaintext) error {
+	p := e.params
+	if err := validateCiphertext(ct, p); err != nil {
+		return err
+	}
+	if err := validatePlaintext(pt, p); err != nil {
+		return err
+	}
+	if ct.Codec != pt.Codec {
+		return ErrCodecMismatch
+	}
+	return nil
+}
+
+// validateAddPlain checks if a plaintext can be added to a ciphertext.
+func (e *Evaluator) validateAddPlain(ct *Ciphertext, pt *Plaintext) error {
+	if err := e.validatePlain(ct, pt); err != nil {
+		return err
+	}
+	return e.checkNoise(e.params.PlainNoise(estimateOf(ct)))
+}
+
+// addPlain adds d*pt to the first component of ct, where d is +delta or -delta.
+func (e *Evaluator) addPlain(ct *Ciphertext, pt *Plaintext, d *big.Int) {
+	r := e.ring
+	c0 := r.Poly(ct.Value[0])
+	c0.Add(c0, r.NewPoly().MulScalar(r.Poly(pt.Value), d))
+	n := e.params.PlainNoise(estimateOf(ct))
+	ct.Noise, ct.AvgNoise = n.Worst, n.Avg
+}
+
+// Add executes the addition of two ciphertexts. The ciphertexts can have different
+// degrees, e.g., a fresh ciphertext and a product that was not relinearized, and
+// the result has the degree of the largest one.
+func (e *Evaluator) Add(ct0, ct1 *Ciphertext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operands.
+	if err := compatible(ct0, ct1, p); err != nil {
+		return nil, err
+	}
+	n := params.SumNoise(estimateOf(ct0), estimateOf(ct1))
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	r := e.ring
+	if ct0.Degree() < ct1.Degree() {
+		ct0, ct1 = ct1, ct0
+	}
+	c := make([][]*big.Int, len(ct0.Value))
 	for i := 0; i < len(c); i++ {
-		s = append(s, VecSymMod(c[i], cm))
+		a := r.NewPoly().CopyFrom(r.Poly(ct0.Value[i]))
+		if i < len(ct1.Value) {
+			a.Add(a, r.Poly(ct1.Value[i]))
+		}
+		c[i] = a.SymModInPlace().Coeffs
+	}
+	return newCiphertext(c, ct0.Codec, n, p), nil
+}
+
+// Sub executes the subtraction of two ciphertexts, which can have different degrees.
+func (e *Evaluator) Sub(ct0, ct1 *Ciphertext) (*Ciphertext, error) {
+	if err := e.validateSub(ct0, ct1); err != nil {
+		return nil, err
+	}
+	c := ct0.Copy()
+	e.sub(c, ct1)
+	return c, nil
+}
+
+// SubInPlace subtracts ct1 from ct0, which holds the result.
+func (e *Evaluator) SubInPlace(ct0, ct1 *Ciphertext) error {
+	if err := e.validateSub(ct0, ct1); err != nil {
+		return err
+	}
+	e.sub(ct0, ct1)
+	return nil
+}
+
+// validateSub checks if two ciphertexts can be subtracted.
+func (e *Evaluator) validateSub(ct0, ct1 *Ciphertext) error {
+	if err := compatible(ct0, ct1, e.params); err != nil {
+		return err
+	}
+	return e.checkNoise(params.SumNoise(estimateOf(ct0), estimateOf(ct1)))
+}
+
+// sub sets ct0 to ct0 - ct1. If ct1 has a larger degree, the missing components
+// of ct0 are taken as zero.
+func (e *Evaluator) sub(ct0, ct1 *Ciphertext) {
+	r := e.ring
+	for len(ct0.Value) < len(ct1.Value) {
+		ct0.Value = append(ct0.Value, r.NewPoly().Coeffs)
+	}
+	for i := 0; i < len(ct1.Value); i++ {
+		c := r.Poly(ct0.Value[i])
+		c.Sub(c, r.Poly(ct1.Value[i])).SymModInPlace()
+	}
+	n := params.SumNoise(estimateOf(ct0), estimateOf(ct1))
+	ct0.Noise, ct0.AvgNoise = n.Worst, n.Avg
+}
+
+// Neg executes the negation of a ciphertext.
+func (e *Evaluator) Neg(ct *Ciphertext) (*Ciphertext, error) {
+	if err := validateCiphertext(ct, e.params); err != nil {
+		return nil, err
+	}
+	c := ct.Copy()
+	e.neg(c)
+	return c, nil
+}
+
+// NegInPlace negates a ciphertext, which holds the result.
+func (e *Evaluator) NegInPlace(ct *Ciphertext) error {
+	if err := validateCiphertext(ct, e.params); err != nil {
+		return err
+	}
+	e.neg(ct)
+	return nil
+}
+
+// neg sets ct to -ct. The negation does not change the noise bound.
+func (e *Evaluator) neg(ct *Ciphertext) {
+	r := e.ring
+	for i := 0; i < len(ct.Value); i++ {
+		c := r.Poly(ct.Value[i])
+		c.Neg(c).SymModInPlace()
 	}
-	return s
 }
 
 // SMult executes the multiplication of a ciphertext by a scalar.
-func (e *Evaluator) SMult(ct [][]*big.Int, s *big.Int) [][]*big.Int {
-	p := e.keychain.Params
-	// Degree.
-	l := p.Size()
-	// Coefficient modulus.
-	cm := big.NewInt(p.CoefficientModulus())
-	//
-	r := make([][]*big.Int, 2)
-	r[0] = make([]*big.Int, l)
-	r[1] = make([]*big.Int, l)
-	for i := 0; i < l; i++ {
-		r[0][i] = big.NewInt(0)
-		r[0][i].Mul(s, ct[0][i])
-		r[1][i] = big.NewInt(0)
-		r[1][i].Mul(s, ct[1][i])
-	}
-	c := make([][]*big.Int, 2)
-	// var s1, s2 []*big.Int
-	c[0] = VecSymMod(r[0], cm)
-	c[1] = VecSymMod(r[1], cm)
-	return c
+func (e *Evaluator) SMult(ct *Ciphertext, s *big.Int) (*Ciphertext, error) {
+	p := e.params
+	// Validate operand.
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	sf, _ := new(big.Float).SetInt(s).Float64()
+	n := params.ScalarNoise(estimateOf(ct), sf)
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	r := e.ring
+	c := make([][]*big.Int, len(ct.Value))
+	for i := 0; i < len(c); i++ {
+		c[i] = r.NewPoly().MulScalar(r.Poly(ct.Value[i]), s).SymModInPlace().Coeffs
+	}
+	return newCiphertext(c, ct.Codec, n, p), nil
 }
 
-// Mult executes the multiplication of two ciphertexts.
-func (e *Evaluator) Mult(ct0, ct1 [][]*big.Int) ([][]*big.Int, error) {
-	m, err := e.multPrime(ct0, ct1)
+// PMult executes the multiplication of a ciphertext by an encoded plaintext polynomial.
+// It does not need the evaluation key, since the degree of the ciphertext does not change.
+func (e *Evaluator) PMult(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operands.
+	if err := e.validatePlain(ct, pt); err != nil {
+		return nil, err
+	}
+	n := params.PlainMultNoise(estimateOf(ct), pt.Value)
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	r := e.ring
+	m := r.Poly(pt.Value)
+	c := make([][]*big.Int, len(ct.Value))
+	for i := 0; i < len(c); i++ {
+		prod, err := r.NewPoly().Mul(r.Poly(ct.Value[i]), m)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = prod.SymModInPlace().Coeffs
+	}
+	return newCiphertext(c, ct.Codec, n, p), nil
+}
+
+// Mult executes the multiplication of two ciphertexts, i.e., the tensor product
+// followed by the relinearization of the result.
+func (e *Evaluator) Mult(ct0, ct1 *Ciphertext) (*Ciphertext, error) {
+	ct, err := e.MultNoRelin(ct0, ct1)
 	if err != nil {
 		return nil, err
 	}
-	c, err := e.relinearize(m)
+	return e.Relinearize(ct)
+}
+
+// MultNoRelin executes the multiplication of two ciphertexts without relinearizing
+// the result, which has degree 2. Products of degree 2 can be added together and
+// relinearized once, e.g., to save the key switching of each term of a dot product.
+func (e *Evaluator) MultNoRelin(ct0, ct1 *Ciphertext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operands.
+	if err := compatible(ct0, ct1, p); err != nil {
+		return nil, err
+	}
+	if ct0.Degree() != 1 || ct1.Degree() != 1 {
+		return nil, ErrCiphertextDegreeIsNotValid
+	}
+	n := p.TensorNoise(estimateOf(ct0), estimateOf(ct1))
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	m, err := e.multPrime(ct0.Value, ct1.Value)
 	if err != nil {
 		return nil, err
 	}
-	return c, nil
+	return newCiphertext(m, ct0.Codec, n, p), nil
 }
 
-func (e *Evaluator) multPrime(ct0, ct1 [][]*big.Int) ([][]*big.Int, error) {
-	params := e.keychain.Params
-	// Degree.
-	n := params.Size()
-	var err error
-	c := make([][]*big.Int, 4)
-	c[0], err = PolyMult(ct0[0], ct1[0], params)
+// Relinearize turns a ciphertext of degree 2 into a ciphertext of degree 1 with
+// the evaluation key.
+func (e *Evaluator) Relinearize(ct *Ciphertext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operand.
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	if ct.Degree() != 2 {
+		return nil, ErrCiphertextDegreeIsNotValid
+	}
+	if len(e.ek) != p.RelinearizationDigits() {
+		return nil, ErrEvaluationKeyIsNotValid
+	}
+	n := p.RelinNoise(estimateOf(ct))
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	c, err := e.relinearize(ct.Value)
 	if err != nil {
 		return nil, err
 	}
-	c[1], err = PolyMult(ct0[0], ct1[1], params)
+	return newCiphertext(c, ct.Codec, n, p), nil
+}
+
+// InnerProduct executes the weighted sum w[0]*ct[0] + ... + w[n-1]*ct[n-1] of
+// ciphertexts by encoded plaintext weights. The products are accumulated over the
+// integers and reduced modulo q once at the end.
+func (e *Evaluator) InnerProduct(cts []*Ciphertext, w []*Plaintext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operands.
+	if len(cts) == 0 || len(cts) != len(w) {
+		return nil, ErrInnerProductLengthIsNotValid
+	}
+	for i := 0; i < len(cts); i++ {
+		if err := e.validatePlain(cts[i], w[i]); err != nil {
+			return nil, err
+		}
+		if err := compatible(cts[0], cts[i], p); err != nil {
+			return nil, err
+		}
+	}
+	n := params.NoNoise
+	for i := 0; i < len(cts); i++ {
+		n = params.SumNoise(n, params.PlainMultNoise(estimateOf(cts[i]), w[i].Value))
+	}
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	r := e.ring
+	var acc []*Poly
+	prod := r.NewPoly()
+	for i := 0; i < len(cts); i++ {
+		m := r.Poly(w[i].Value)
+		for j := 0; j < len(cts[i].Value); j++ {
+			if j == len(acc) {
+				acc = append(acc, r.NewPoly())
+			}
+			if _, err := prod.Mul(r.Poly(cts[i].Value[j]), m); err != nil {
+				return nil, err
+			}
+			acc[j].Add(acc[j], prod)
+		}
+	}
+	return newCiphertext(symMod(acc), cts[0].Codec, n, p), nil
+}
+
+// InnerProductEncrypted executes the inner product ct0[0]*ct1[0] + ... + ct0[n-1]*ct1[n-1]
+// of two vectors of ciphertexts. The tensor products are accumulated over the
+// integers, so that the sum is relinearized and reduced modulo q only once.
+func (e *Evaluator) InnerProductEncrypted(cts0, cts1 []*Ciphertext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operands.
+	if len(cts0) == 0 || len(cts0) != len(cts1) {
+		return nil, ErrInnerProductLengthIsNotValid
+	}
+	for i := 0; i < len(cts0); i++ {
+		if err := compatible(cts0[i], cts1[i], p); err != nil {
+			return nil, err
+		}
+		if err := compatible(cts0[0], cts0[i], p); err != nil {
+			return nil, err
+		}
+		if cts0[i].Degree() != 1 || cts1[i].Degree() != 1 {
+			return nil, ErrCiphertextDegreeIsNotValid
+		}
+	}
+	if len(e.ek) != p.RelinearizationDigits() {
+		return nil, ErrEvaluationKeyIsNotValid
+	}
+	n := params.NoNoise
+	for i := 0; i < len(cts0); i++ {
+		n = params.SumNoise(n, p.TensorNoise(estimateOf(cts0[i]), estimateOf(cts1[i])))
+	}
+	n = p.RelinNoise(n)
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	r := e.ring
+	acc := []*Poly{r.NewPoly(), r.NewPoly(), r.NewPoly()}
+	for i := 0; i < len(cts0); i++ {
+		d, err := e.tensor(cts0[i].Value, cts1[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < len(acc); j++ {
+			acc[j].Add(acc[j], d[j])
+		}
+	}
+	// The expansion of the relinearization needs the last component in the range of q.
+	acc[2].SymModInPlace()
+	c, err := e.relinearize([][]*big.Int{acc[0].Coeffs, acc[1].Coeffs, acc[2].Coeffs})
 	if err != nil {
 		return nil, err
 	}
-	c[2], err = PolyMult(ct0[1], ct1[0], params)
+	return newCiphertext(symMod([]*Poly{r.Poly(c[0]), r.Poly(c[1])}), cts0[0].Codec, n, p), nil
+}
+
+// Square executes the multiplication of a ciphertext by itself. It is the same as
+// Mult(ct, ct), but the tensor product is symmetric and needs one product less.
+func (e *Evaluator) Square(ct *Ciphertext) (*Ciphertext, error) {
+	p := e.params
+	// Validate operand.
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	if ct.Degree() != 1 {
+		return nil, ErrCiphertextDegreeIsNotValid
+	}
+	if len(e.ek) != p.RelinearizationDigits() {
+		return nil, ErrEvaluationKeyIsNotValid
+	}
+	n := p.MultNoise(estimateOf(ct), estimateOf(ct))
+	if err := e.checkNoise(n); err != nil {
+		return nil, err
+	}
+	m, err := e.squarePrime(ct.Value)
 	if err != nil {
 		return nil, err
 	}
-	c[3], err = PolyMult(ct0[1], ct1[1], params)
+	c, err := e.relinearize(m)
 	if err != nil {
 		return nil, err
 	}
+	return newCiphertext(c, ct.Codec, n, p), nil
+}
 
-	c0 := Func1(c[0], params)
+// Pow executes the exponentiation of a ciphertext by a positive integer k. The
+// power is computed as x^k = x^ceil(k/2) * x^floor(k/2), which reuses the
+// intermediate powers as in square-and-multiply and has the minimal
+// multiplicative depth ceil(log2(k)). It fails before any multiplication if the
+// noise estimate of the result exceeds the noise budget of the parameters, with
+// the worst-case estimate unless the evaluator checks the average case.
+func (e *Evaluator) Pow(ct *Ciphertext, k int) (*Ciphertext, error) {
+	p := e.params
+	// Validate operand.
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	if ct.Degree() != 1 {
+		return nil, ErrCiphertextDegreeIsNotValid
+	}
+	if k < 1 {
+		return nil, ErrExponentIsNotValid
+	}
+	if k == 1 {
+		return ct.Copy(), nil
+	}
+	// Noise budget, which is always checked, in the worst case by default.
+	c := e.check
+	if c == NoiseCheckNone {
+		c = NoiseCheckWorst
+	}
+	if err := e.checkNoiseWith(c, p.PowNoise(estimateOf(ct), k)); err != nil {
+		return nil, err
+	}
+	return e.pow(map[int]*Ciphertext{1: ct}, k)
+}
 
-	c12 := make([]*big.Int, n)
-	for i := 0; i < len(c12); i++ {
-		c12[i] = big.NewInt(0)
-		c12[i].Add(c12[i], c[1][i])
-		c12[i].Add(c12[i], c[2][i])
+// pow computes the k-th power from the powers already computed in pw.
+func (e *Evaluator) pow(pw map[int]*Ciphertext, k int) (*Ciphertext, error) {
+	if c, ok := pw[k]; ok {
+		return c, nil
+	}
+	h, err := e.pow(pw, k/2)
+	if err != nil {
+		return nil, err
 	}
-	c1 := Func1(c12, params)
-	c2 := Func1(c[3], params)
+	var c *Ciphertext
+	if k%2 == 0 {
+		c, err = e.Square(h)
+	} else {
+		var h1 *Ciphertext
+		if h1, err = e.pow(pw, k/2+1); err != nil {
+			return nil, err
+		}
+		c, err = e.Mult(h1, h)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pw[k] = c
+	return c, nil
+}
 
-	return [][]*big.Int{c0, c1, c2}, nil
+// multPrime calculates round(t/q * ct0 x ct1), i.e., the tensor product of the
+// ciphertexts scaled down by t/q, which has 3 components.
+func (e *Evaluator) multPrime(ct0, ct1 [][]*big.Int) ([][]*big.Int, error) {
+	d, err := e.tensor(ct0, ct1)
+	if err != nil {
+		return nil, err
+	}
+	return symMod(d), nil
 }
 
-func (e *Evaluator) func2(expanded_ct [][]*big.Int, index int) ([][]*big.Int, error) {
-	params := e.keychain.Params
-	l := CoeffExpLen(params)
-	n := params.Size()
-	var err error
-	prod := make([][]*big.Int, l)
-	for j := 0; j < len(prod); j++ {
-		a := e.keychain.EK[j][index]
-		b := make([]*big.Int, n)
-		for i := 0; i < n; i++ {
-			b[i] = expanded_ct[i][j]
+// tensor calculates round(t/q * ct0 x ct1) without reducing it modulo q.
+func (e *Evaluator) tensor(ct0, ct1 [][]*big.Int) ([]*Poly, error) {
+	params := e.params
+	r := e.ring
+	// The tensor product is scaled down by t/q, so it must be exact over the integers.
+	c := make([]*Poly, 4)
+	for i, ij := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+		prod, err := IntPolyMult(ct0[ij[0]], ct1[ij[1]], params)
+		if err != nil {
+			return nil, err
 		}
-		prod[j], err = PolyMult(a, b, params)
+		c[i] = r.Poly(prod)
+	}
+	c[1].Add(c[1], c[2])
+	return e.scaleDown(c[0], c[1], c[3]), nil
+}
+
+// squarePrime calculates round(t/q * ct x ct). Since the tensor product is symmetric,
+// the middle component is 2*c0*c1 and the product c1*c0 is not needed.
+func (e *Evaluator) squarePrime(ct [][]*big.Int) ([][]*big.Int, error) {
+	params := e.params
+	r := e.ring
+	c := make([]*Poly, 3)
+	for i, ij := range [][2]int{{0, 0}, {0, 1}, {1, 1}} {
+		prod, err := IntPolyMult(ct[ij[0]], ct[ij[1]], params)
 		if err != nil {
 			return nil, err
 		}
+		c[i] = r.Poly(prod)
+	}
+	c[1].Add(c[1], c[1])
+	return symMod(e.scaleDown(c[0], c[1], c[2])), nil
+}
+
+// scaleDown scales the components of a tensor product by t/q in place.
+func (e *Evaluator) scaleDown(d0, d1, d2 *Poly) []*Poly {
+	t := e.params.DecryptionModulus()
+	for _, d := range []*Poly{d0, d1, d2} {
+		d.divRound(t, e.ring.q)
 	}
-	return prod, nil
+	return []*Poly{d0, d1, d2}
 }
 
-func (e *Evaluator) relinearize(prod [][]*big.Int) ([][]*big.Int, error) {
-	p := e.keychain.Params
-	ct := make([][]*big.Int, p.Size())
-	l := CoeffExpLen(p)
-	for i := 0; i < len(ct); i++ {
-		ct[i] = utils.Exp(prod[2][i], l, p.RelinearizationExpansionBase())
+// symMod reduces the polynomials modulo q in place and returns their coefficients.
+func symMod(d []*Poly) [][]*big.Int {
+	c := make([][]*big.Int, len(d))
+	for i := 0; i < len(d); i++ {
+		c[i] = d[i].SymModInPlace().Coeffs
 	}
+	return c
+}
 
+// relinearize turns a ciphertext with 3 components into one with 2 components.
+// The last component is expanded in the relinearization expansion base w, i.e.,
+// d2 = sum(g_j * w^j), and each digit g_j is multiplied by the evaluation key j.
+func (e *Evaluator) relinearize(d [][]*big.Int) ([][]*big.Int, error) {
+	p := e.params
+	r := e.ring
+	l := p.RelinearizationDigits()
+	w := p.RelinearizationExpansionBase()
+	// Digits of the expansion of each coefficient.
+	exp := make([][]*big.Int, p.Size())
+	for i := 0; i < len(exp); i++ {
+		exp[i] = utils.BigExp(d[2][i], l, w)
+	}
 	// Relinearized ciphertext.
-	var c [][]*big.Int
-	for i := 0; i < 2; i++ {
-		prodF, err := e.func2(ct, i)
-		if err != nil {
+	c0 := r.NewPoly().CopyFrom(r.Poly(d[0]))
+	c1 := r.NewPoly().CopyFrom(r.Poly(d[1]))
+	g, prod := r.NewPoly(), r.NewPoly()
+	for j := 0; j < l; j++ {
+		for i := 0; i < len(exp); i++ {
+			g.Coeffs[i].Set(exp[i][j])
+		}
+		if _, err := prod.Mul(r.Poly(e.ek[j][0]), g); err != nil {
+			return nil, err
+		}
+		c0.Add(c0, prod)
+		if _, err := prod.Mul(r.Poly(e.ek[j][1]), g); err != nil {
 			return nil, err
 		}
-		c = append(c, Func345(prod[i], prodF, p))
+		c1.Add(c1, prod)
 	}
-
-	return c, nil
+	return [][]*big.Int{c0.Coeffs, c1.Coeffs}, nil
 }
@@ -0,0 +1,244 @@
+package scheme
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// secretKeyJSON is the JSON form of SecretKey.
+type secretKeyJSON struct {
+	Params params.Literal `json:"params"`
+	Value  []string       `json:"value"`
+}
+
+// publicKeyJSON is the JSON form of PublicKey.
+type publicKeyJSON struct {
+	Params params.Literal `json:"params"`
+	Seed   []byte         `json:"seed,omitempty"`
+	Value  [][]string     `json:"value"`
+}
+
+// evaluationKeyJSON is the JSON form of EvaluationKey.
+type evaluationKeyJSON struct {
+	Params params.Literal `json:"params"`
+	Seed   []byte         `json:"seed,omitempty"`
+	Value  [][][]string   `json:"value"`
+}
+
+// ciphertextJSON is the JSON form of Ciphertext.
+type ciphertextJSON struct {
+	Scheme      int        `json:"scheme"`
+	Codec       Codec      `json:"codec"`
+	Fingerprint string     `json:"fingerprint"`
+	Noise       float64    `json:"noise"`
+	AvgNoise    float64    `json:"avgNoise"`
+	Value       [][]string `json:"value"`
+}
+
+// MarshalJSON encodes the secret key with its parameters, and its coefficients as
+// decimal strings.
+func (sk *SecretKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(secretKeyJSON{Params: sk.Params.Literal, Value: decimals(sk.Value)})
+}
+
+// UnmarshalJSON decodes a secret key encoded by MarshalJSON.
+func (sk *SecretKey) UnmarshalJSON(data []byte) error {
+	var kj secretKeyJSON
+	if err := json.Unmarshal(data, &kj); err != nil {
+		return err
+	}
+	p, err := params.New(kj.Params)
+	if err != nil {
+		return err
+	}
+	v, ok := parseDecimals(kj.Value)
+	if !ok || !validSK(v, p) {
+		return ErrKeyIsNotValid
+	}
+	sk.Value, sk.Params = v, p
+	return nil
+}
+
+// MarshalJSON encodes the public key with its parameters, and its coefficients as
+// decimal strings. As in the binary format, a key with a seed is encoded with the
+// seed in place of a.
+func (pk *PublicKey) MarshalJSON() ([]byte, error) {
+	ks := &Keystorage{PK: pk.Value, PKSeed: pk.Seed}
+	ks.compress()
+	v := make([][]string, len(ks.PK))
+	for i := 0; i < len(v); i++ {
+		v[i] = decimals(ks.PK[i])
+	}
+	return json.Marshal(publicKeyJSON{Params: pk.Params.Literal, Seed: pk.Seed, Value: v})
+}
+
+// UnmarshalJSON decodes a public key encoded by MarshalJSON.
+func (pk *PublicKey) UnmarshalJSON(data []byte) error {
+	var kj publicKeyJSON
+	if err := json.Unmarshal(data, &kj); err != nil {
+		return err
+	}
+	p, err := params.New(kj.Params)
+	if err != nil {
+		return err
+	}
+	v := make([][]*big.Int, len(kj.Value))
+	for i := 0; i < len(v); i++ {
+		var ok bool
+		if v[i], ok = parseDecimals(kj.Value[i]); !ok {
+			return ErrKeyIsNotValid
+		}
+	}
+	// The seed expands to a.
+	ks := &Keystorage{PK: v, PKSeed: kj.Seed}
+	if err := ks.expand(p); err != nil {
+		return err
+	}
+	if !validPK(ks.PK, p) {
+		return ErrKeyIsNotValid
+	}
+	pk.Value, pk.Seed, pk.Params = ks.PK, kj.Seed, p
+	return nil
+}
+
+// MarshalJSON encodes the evaluation key with its parameters, and its coefficients
+// as decimal strings. As in the binary format, a key with a seed is encoded with the
+// seed in place of the a_i.
+func (ek *EvaluationKey) MarshalJSON() ([]byte, error) {
+	ks := &Keystorage{EK: ek.Value, EKSeed: ek.Seed}
+	ks.compress()
+	v := make([][][]string, len(ks.EK))
+	for i := 0; i < len(v); i++ {
+		v[i] = make([][]string, len(ks.EK[i]))
+		for j := 0; j < len(v[i]); j++ {
+			v[i][j] = decimals(ks.EK[i][j])
+		}
+	}
+	return json.Marshal(evaluationKeyJSON{Params: ek.Params.Literal, Seed: ek.Seed, Value: v})
+}
+
+// UnmarshalJSON decodes an evaluation key encoded by MarshalJSON.
+func (ek *EvaluationKey) UnmarshalJSON(data []byte) error {
+	var kj evaluationKeyJSON
+	if err := json.Unmarshal(data, &kj); err != nil {
+		return err
+	}
+	p, err := params.New(kj.Params)
+	if err != nil {
+		return err
+	}
+	v := make([][][]*big.Int, len(kj.Value))
+	for i := 0; i < len(v); i++ {
+		v[i] = make([][]*big.Int, len(kj.Value[i]))
+		for j := 0; j < len(v[i]); j++ {
+			var ok bool
+			if v[i][j], ok = parseDecimals(kj.Value[i][j]); !ok {
+				return ErrKeyIsNotValid
+			}
+		}
+	}
+	// The seed expands to the a_i.
+	ks := &Keystorage{EK: v, EKSeed: kj.Seed}
+	if err := ks.expand(p); err != nil {
+		return err
+	}
+	if !validEK(ks.EK, p) {
+		return ErrKeyIsNotValid
+	}
+	ek.Value, ek.Seed, ek.Params = ks.EK, kj.Seed, p
+	return nil
+}
+
+// MarshalJSON encodes the ciphertext with its coefficients as decimal strings and
+// the fingerprint of its parameters as a hexadecimal string. As in MarshalBinary,
+// the coefficients are reduced modulo q when the parameters are known.
+func (ct *Ciphertext) MarshalJSON() ([]byte, error) {
+	v := make([][]string, len(ct.Value))
+	for i := 0; i < len(v); i++ {
+		if ct.params != nil {
+			v[i] = decimals(VecSymMod(ct.Value[i], ct.params.CoefficientModulus()))
+		} else {
+			v[i] = decimals(ct.Value[i])
+		}
+	}
+	return json.Marshal(ciphertextJSON{
+		Scheme:      ct.Scheme,
+		Codec:       ct.Codec,
+		Fingerprint: fmt.Sprintf("%016x", ct.Fingerprint),
+		Noise:       ct.Noise,
+		AvgNoise:    ct.AvgNoise,
+		Value:       v,
+	})
+}
+
+// UnmarshalJSON decodes a ciphertext encoded by MarshalJSON. It returns
+// ErrCiphertextDataIsNotValid for coefficients that are not decimal integers or
+// components of different sizes. Since the parameters are not part of the data,
+// UnmarshalCiphertextJSON checks the ciphertext against them, and the ciphertext
+// decoded by it can also be encoded by MarshalBinary.
+func (ct *Ciphertext) UnmarshalJSON(data []byte) error {
+	var cj ciphertextJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+	fingerprint, err := strconv.ParseUint(cj.Fingerprint, 16, 64)
+	if err != nil {
+		return ErrCiphertextDataIsNotValid
+	}
+	if len(cj.Value) < 2 {
+		return ErrCiphertextDataIsNotValid
+	}
+	v := make([][]*big.Int, len(cj.Value))
+	for i := 0; i < len(v); i++ {
+		var ok bool
+		if v[i], ok = parseDecimals(cj.Value[i]); !ok || len(v[i]) == 0 || len(v[i]) != len(cj.Value[0]) {
+			return ErrCiphertextDataIsNotValid
+		}
+	}
+	*ct = Ciphertext{Value: v, Fingerprint: fingerprint, Scheme: cj.Scheme, Codec: cj.Codec, Noise: cj.Noise, AvgNoise: cj.AvgNoise}
+	return nil
+}
+
+// UnmarshalCiphertextJSON decodes a ciphertext encoded by MarshalJSON with the
+// parameters p. Besides the checks of UnmarshalJSON, the ciphertext must match
+// the parameters, and its coefficients must be reduced modulo q, i.e., in
+// [-floor(q/2), ceil(q/2)).
+func UnmarshalCiphertextJSON(data []byte, p *params.Params) (*Ciphertext, error) {
+	ct := new(Ciphertext)
+	if err := ct.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, err
+	}
+	if err := validateReduced(ct, p.CoefficientModulus()); err != nil {
+		return nil, err
+	}
+	ct.params = p
+	return ct, nil
+}
+
+// decimals returns the decimal strings of the coefficients.
+func decimals(c []*big.Int) []string {
+	s := make([]string, len(c))
+	for i := 0; i < len(s); i++ {
+		s[i] = c[i].String()
+	}
+	return s
+}
+
+// parseDecimals parses the decimal strings of coefficients.
+func parseDecimals(s []string) ([]*big.Int, bool) {
+	c := make([]*big.Int, len(s))
+	for i := 0; i < len(c); i++ {
+		var ok bool
+		if c[i], ok = new(big.Int).SetString(s[i], 10); !ok {
+			return nil, false
+		}
+	}
+	return c, true
+}
@@ -0,0 +1,233 @@
+package scheme
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// testKeychains returns PLHERatio16 and PLBFV32 keychains without seeds, as in
+// the key files of earlier versions, and a PLBFV32 keychain with seeds. They are
+// created in memory, so that they do not depend on the key files of other tests.
+func testKeychains(t *testing.T) map[string]*Keychain {
+	ks := NewMemoryKeyStore()
+	kcs := make(map[string]*Keychain)
+	for name, pl := range map[string]params.Literal{
+		"PLHERatio16.kc": params.PLHERatio16,
+		"PLBFV32.kc":     params.PLBFV32,
+		"seeded":         params.PLBFV32,
+	} {
+		p, err := params.New(pl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kc, err := SetupKeyStore(ks, name, new(oracle.Oracle), p, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "seeded" {
+			kc.PKSeed, kc.EKSeed = nil, nil
+		}
+		kcs[name] = kc
+	}
+	return kcs
+}
+
+func TestKeyJSON(t *testing.T) {
+	for name, kc := range testKeychains(t) {
+		// Case: secret key.
+		data, err := json.Marshal(kc.SecretKey())
+		if err != nil {
+			t.Error(err)
+		}
+		sk := new(SecretKey)
+		if err := json.Unmarshal(data, sk); err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(sk.Value, kc.SK) || sk.Params.Fingerprint() != kc.Params.Fingerprint() {
+			t.Errorf("expected the secret key of %s to be restored", name)
+		}
+		// Case: public key.
+		data, err = json.Marshal(kc.PublicKey())
+		if err != nil {
+			t.Error(err)
+		}
+		if s := `"` + kc.PK[0][0].String() + `"`; !strings.Contains(string(data), s) {
+			t.Errorf("expected the coefficient %s in the public key of %s", s, name)
+		}
+		pk := new(PublicKey)
+		if err := json.Unmarshal(data, pk); err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(pk.Value[0], kc.PK[0]) || !equalPolys(pk.Value[1], kc.PK[1]) || string(pk.Seed) != string(kc.PKSeed) {
+			t.Errorf("expected the public key of %s to be restored", name)
+		}
+		// Case: evaluation key.
+		data, err = json.Marshal(kc.EvaluationKey())
+		if err != nil {
+			t.Error(err)
+		}
+		ek := new(EvaluationKey)
+		if err := json.Unmarshal(data, ek); err != nil {
+			t.Error(err)
+		}
+		for i := 0; i < len(kc.EK); i++ {
+			if !equalPolys(ek.Value[i][0], kc.EK[i][0]) || !equalPolys(ek.Value[i][1], kc.EK[i][1]) {
+				t.Errorf("expected pair [%d] of the evaluation key of %s to be restored", i, name)
+			}
+		}
+	}
+}
+
+func TestKeyJSONErrors(t *testing.T) {
+	kc := testKeychains(t)["seeded"]
+	data, err := json.Marshal(kc.PublicKey())
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: coefficients that are not decimal integers.
+	bad := strings.Replace(string(data), `"`+kc.PK[0][0].String()+`"`, `"x"`, 1)
+	if err := json.Unmarshal([]byte(bad), new(PublicKey)); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	// Case: the seed is encoded in place of a.
+	var kj publicKeyJSON
+	if err := json.Unmarshal(data, &kj); err != nil {
+		t.Error(err)
+	}
+	if len(kj.Value) != 1 || string(kj.Seed) != string(kc.PKSeed) {
+		t.Errorf("expected one component and the seed but got %d components", len(kj.Value))
+	}
+	// Case: a seed along with a.
+	kj.Value = [][]string{decimals(kc.PK[0]), decimals(kc.PK[1])}
+	if data, err = json.Marshal(kj); err != nil {
+		t.Error(err)
+	}
+	if err := json.Unmarshal(data, new(PublicKey)); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	// Case: a secret key of the wrong size.
+	if data, err = json.Marshal(&SecretKey{Value: kc.SK[1:], Params: kc.Params}); err != nil {
+		t.Error(err)
+	}
+	if err := json.Unmarshal(data, new(SecretKey)); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+}
+
+func TestCiphertextJSON(t *testing.T) {
+	for name, kc := range testKeychains(t) {
+		cip, err := NewCipher(kc)
+		if err != nil {
+			t.Error(err)
+		}
+		// Raw message.
+		m := make([]*big.Int, kc.Params.Size())
+		for i := 0; i < len(m); i++ {
+			m[i] = big.NewInt(int64(i%5) - 2)
+		}
+		ct, err := cip.Enc(NewPlaintext(m, CodecNone, kc.Params))
+		if err != nil {
+			t.Error(err)
+		}
+		// Case: the decoded ciphertext decrypts to the message.
+		data, err := json.Marshal(ct)
+		if err != nil {
+			t.Error(err)
+		}
+		dct := new(Ciphertext)
+		if err := json.Unmarshal(data, dct); err != nil {
+			t.Error(err)
+		}
+		if dct.Fingerprint != ct.Fingerprint || dct.Scheme != ct.Scheme || dct.Codec != ct.Codec || dct.Noise != ct.Noise || dct.AvgNoise != ct.AvgNoise {
+			t.Errorf("expected the header of the ciphertext of %s to be restored", name)
+		}
+		mr, err := cip.Dec(dct)
+		if err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(mr.Value, m) {
+			t.Errorf("expected the decoded ciphertext of %s to decrypt to the message", name)
+		}
+		// Case: decoded with its parameters, the ciphertext can be encoded in binary.
+		pct, err := UnmarshalCiphertextJSON(data, kc.Params)
+		if err != nil {
+			t.Error(err)
+		}
+		bd, err := ct.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		if d, err := pct.MarshalBinary(); err != nil || string(d) != string(bd) {
+			t.Errorf("expected the decoded ciphertext of %s to be encoded in binary but got %v", name, err)
+		}
+		// Case: products, whose coefficients are not reduced, are encoded reduced.
+		prod, err := NewEvaluator(kc).Mult(ct, ct)
+		if err != nil {
+			t.Error(err)
+		}
+		if data, err = json.Marshal(prod); err != nil {
+			t.Error(err)
+		}
+		if pct, err = UnmarshalCiphertextJSON(data, kc.Params); err != nil {
+			t.Error(err)
+		}
+		m1, err := cip.Dec(prod)
+		if err != nil {
+			t.Error(err)
+		}
+		if m2, err := cip.Dec(pct); err != nil || !equalPolys(m1.Value, m2.Value) {
+			t.Errorf("expected the decoded product of %s to decrypt to the product but got %v", name, err)
+		}
+	}
+	// Case: ciphertexts that do not match the parameters.
+	kcs := testKeychains(t)
+	p := kcs["PLBFV32.kc"].Params
+	cip, err := NewCipher(kcs["PLBFV32.kc"])
+	if err != nil {
+		t.Error(err)
+	}
+	m := make([]*big.Int, p.Size())
+	for i := 0; i < len(m); i++ {
+		m[i] = big.NewInt(1)
+	}
+	ct, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+	if err != nil {
+		t.Error(err)
+	}
+	out := ct.Copy()
+	out.params = nil
+	out.Value[1][0].Set(p.CoefficientModulus())
+	for _, tt := range []struct {
+		name string
+		ct   *Ciphertext
+		p    *params.Params
+		err  error
+	}{
+		{"parameters", ct, kcs["PLHERatio16.kc"].Params, ErrSchemeMismatch},
+		{"coefficient", out, p, ErrCiphertextDataIsNotValid},
+	} {
+		data, err := json.Marshal(tt.ct)
+		if err != nil {
+			t.Error(err)
+		}
+		if _, err := UnmarshalCiphertextJSON(data, tt.p); err != tt.err {
+			t.Errorf("expected error %v for %s but got %v", tt.err, tt.name, err)
+		}
+	}
+	// Case: malformed ciphertexts.
+	for _, data := range []string{
+		`{"fingerprint":"xyz","value":[["1"],["2"]]}`,
+		`{"fingerprint":"01","value":[["1"]]}`,
+		`{"fingerprint":"01","value":[["1"],["2","3"]]}`,
+		`{"fingerprint":"01","value":[["1"],["1.5"]]}`,
+	} {
+		if err := json.Unmarshal([]byte(data), new(Ciphertext)); err != ErrCiphertextDataIsNotValid {
+			t.Errorf("expected error %s for %s but got %v", ErrCiphertextDataIsNotValid, data, err)
+		}
+	}
+}
@@ -1,19 +1,14 @@
 package scheme
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/gob"
-	"math"
 	"math/big"
-	"os"
 
 	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
 )
 
 const (
-	Dir = "./keys/"
+	Dir = "./keys/" // Directory of the key files of Setup.
 )
 
 // Keychain manages the secret, public, and evaluation keys.
@@ -22,17 +17,59 @@ type Keychain struct {
 	SK     []*big.Int        // Secret key.
 	PK     [][]*big.Int      // Public key.
 	EK     [][][]*big.Int    // Evaluation key.
+	PKSeed []byte            // Seed of the uniform component of the public key.
+	EKSeed []byte            // Seed of the uniform components of the evaluation key.
 	Params *params.Params    // Parameters.
 }
 
 // Keystorage organizes the secret, public, evaluation keys and literal parameters to be stored.
+// The uniform components of the keys with a seed are not stored, but expanded from the seed.
 type Keystorage struct {
 	SK      []*big.Int     // Secret key.
 	PK      [][]*big.Int   // Public key.
 	EK      [][][]*big.Int // Evaluation key.
+	PKSeed  []byte         // Seed of the public key.
+	EKSeed  []byte         // Seed of the evaluation key.
 	Literal params.Literal // Parameters.
 }
 
+// legacyLiteral mirrors params.Literal as it was stored when the moduli were int64 values.
+type legacyLiteral struct {
+	Degree                       int
+	ExpansionBase                int64
+	CoefficientModulus           int64
+	DecryptionModulus            int64
+	RelinearizationExpansionBase int64
+	StandardDeviation            float64
+	Bound                        int
+	Factor                       int
+	Scheme                       int
+}
+
+// legacyKeystorage mirrors Keystorage for files holding a legacyLiteral.
+type legacyKeystorage struct {
+	SK      []*big.Int
+	PK      [][]*big.Int
+	EK      [][][]*big.Int
+	Literal legacyLiteral
+}
+
+// keystorage converts the legacy storage into the current one.
+func (lks *legacyKeystorage) keystorage() *Keystorage {
+	l := lks.Literal
+	return &Keystorage{SK: lks.SK, PK: lks.PK, EK: lks.EK, Literal: params.Literal{
+		Degree:                       l.Degree,
+		ExpansionBase:                l.ExpansionBase,
+		CoefficientModulus:           big.NewInt(l.CoefficientModulus),
+		DecryptionModulus:            big.NewInt(l.DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(l.RelinearizationExpansionBase),
+		StandardDeviation:            l.StandardDeviation,
+		Bound:                        l.Bound,
+		Factor:                       l.Factor,
+		Scheme:                       l.Scheme,
+	}}
+}
+
 // NewKeychain instantiates a new Keychain with a secret, public and evaluation keys.
 func NewKeychain(o oracle.Randomizer, p *params.Params) (*Keychain, error) {
 	// Error variable.
@@ -48,6 +85,13 @@ func NewKeychain(o oracle.Randomizer, p *params.Params) (*Keychain, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Seeds of the uniform components of the public and evaluation keys.
+	if kc.PKSeed, err = newSeed(o); err != nil {
+		return nil, err
+	}
+	if kc.EKSeed, err = newSeed(o); err != nil {
+		return nil, err
+	}
 	// Public key.
 	kc.PK, err = kc.GenPK()
 	if err != nil {
@@ -71,174 +115,210 @@ func (kc *Keychain) GenSK() ([]*big.Int, error) {
 	return sk, nil
 }
 
-// GenPK generates the *big.Int values of the public key.
+// GenPK generates the *big.Int values of the public key. The uniform component is
+// expanded from PKSeed when it is set, and sampled from the oracle otherwise.
 func (kc *Keychain) GenPK() ([][]*big.Int, error) {
 	// Size.
 	n := kc.Params.Size()
-	// We generate random numbers in the range [lower bound, upper bound).
-	// The lower and upper bound are defined by [-ceil((q-1)/2), floor((q-1)/2)).
-	f := float64(kc.Params.CoefficientModulus()-1) / 2
-	lb := int64(-math.Ceil(f))
-	ub := int64(math.Floor(f))
 	// Sample random numbers.
-	rn, err := kc.O.RandInt(lb, ub, n)
+	var rn []*big.Int
+	var err error
+	if kc.PKSeed != nil {
+		rn, err = expandPK(kc.PKSeed, kc.Params)
+	} else {
+		lb, ub := pkBounds(kc.Params)
+		rn, err = kc.O.RandBigInt(lb, ub, n)
+	}
 	if err != nil {
 		return nil, err
 	}
 	// Samples from a normal distribution.
 	nd := kc.O.NormDist(n)
+	m, err := PolyMult(rn, kc.SK, kc.Params)
+	if err != nil {
+		return nil, err
+	}
 	pm := make([]*big.Int, n)
 	for i := 0; i < len(pm); i++ {
-
-		var m []*big.Int
-
-		m, err = PolyMult(rn, kc.SK, kc.Params)
-		if err != nil {
-			return nil, err
-		}
-
 		pm[i] = big.NewInt(-1)
 		pm[i].Mul(pm[i], m[i])
 	}
 	// pk = [VecSymMod([sum(i) for i in zip(minus_mult,e)],q) , a ]
 	z := SumZip(pm, nd, kc.Params)
 	// VecSymMod.
-	bcm := big.NewInt(kc.Params.CoefficientModulus())
-	vsm := VecSymMod(z, bcm)
+	vsm := VecSymMod(z, kc.Params.CoefficientModulus())
 	// return public key.
 	return [][]*big.Int{vsm, rn}, nil
 }
 
-// GenEK generates the *big.Int values of the evaluation key.
+// GenEK generates the *big.Int values of the evaluation key. The uniform components
+// are expanded from EKSeed when it is set, and sampled from the oracle otherwise.
 func (kc *Keychain) GenEK() ([][][]*big.Int, error) {
 	// Size.
 	n := kc.Params.Size()
 	// Length of coefficient expansion.
-	l := CoeffExpLen(kc.Params)
+	l := kc.Params.RelinearizationDigits()
 	// Evaluation key.
 	evalKey := make([][][]*big.Int, l)
-	// Lower and upper bounds.
-	f := float64(kc.Params.CoefficientModulus()-1) / 2
-	lb := int64(-math.Ceil(f))
-	ub := int64(math.Floor(f)) + 1
-	bcm := big.NewInt(kc.Params.CoefficientModulus())
+	// Lower and upper bounds, i.e., [-ceil((q-1)/2), floor((q-1)/2)].
+	lb, ub := ekBounds(kc.Params)
+	// Uniform components expanded from the seed.
+	var as [][]*big.Int
+	if kc.EKSeed != nil {
+		var err error
+		if as, err = expandEK(kc.EKSeed, kc.Params); err != nil {
+			return nil, err
+		}
+	}
+	bcm := kc.Params.CoefficientModulus()
+	// Relinearization expansion base and its powers, starting at w^0.
+	w := kc.Params.RelinearizationExpansionBase()
+	rmi := big.NewInt(1)
+	// Square of the secret key.
+	pm2, err := PolyMult(kc.SK, kc.SK, kc.Params)
+	if err != nil {
+		return nil, err
+	}
 	for i := 0; i < len(evalKey); i++ {
 		// Sample random numbers.
-		rn, err := kc.O.RandInt(lb, ub, n)
-		if err != nil {
+		var rn []*big.Int
+		if as != nil {
+			rn = as[i]
+		} else if rn, err = kc.O.RandBigInt(lb, ub, n); err != nil {
 			return nil, err
 		}
 		// Samples from a normal distribution.
 		nd := kc.O.NormDist(n)
+		pm1, err := PolyMult(rn, kc.SK, kc.Params)
+		if err != nil {
+			return nil, err
+		}
 		ek := make([]*big.Int, kc.Params.Size())
 		for j := 0; j < len(ek); j++ {
-			pm1, err := PolyMult(rn, kc.SK, kc.Params)
-			if err != nil {
-				return nil, err
-			}
-			pm2, err := PolyMult(kc.SK, kc.SK, kc.Params)
-			if err != nil {
-				return nil, err
-			}
-
-			// relinearize_modulus^i.
-			rmi := int64(math.Pow(float64(kc.Params.RelinearizationExpansionBase()), float64(i)))
-
-			pm1[j].Neg(pm1[j])
-			pm2[j].Mul(pm2[j], big.NewInt(rmi))
-
 			ek[j] = big.NewInt(0)
-			ek[j].Add(ek[j], pm1[j])
+			ek[j].Sub(ek[j], pm1[j])
 			ek[j].Add(ek[j], nd[j])
-			ek[j].Add(ek[j], pm2[j])
+			ek[j].Add(ek[j], new(big.Int).Mul(pm2[j], rmi))
 		}
 		evalKey[i] = [][]*big.Int{VecSymMod(ek, bcm), rn}
+		// relinearize_modulus^(i+1).
+		rmi.Mul(rmi, w)
 	}
 	return evalKey, nil
 }
 
-// Setup returns the stored keys or creates new ones in the directory defined by the FilenameDir constant.
-func Setup(filename string, o oracle.Randomizer, params *params.Params) (*Keychain, error) {
-	filepath := Dir + filename
-	// Try to restore keychain.
-	kc := new(Keychain)
-	if err := kc.unmarshal(filepath); err != nil {
-		// If file does not exist create one.
-		kc, err = NewKeychain(o, params)
-		if err != nil {
-			return nil, err
-		}
-		// Store keychain.
-		if err = kc.marshal(filepath); err != nil {
-			return kc, err
-		}
+// coeffBounds returns the bounds -ceil((q-1)/2) and floor((q-1)/2).
+func coeffBounds(p *params.Params) (*big.Int, *big.Int) {
+	// q - 1.
+	qm1 := p.CoefficientModulus()
+	qm1.Sub(qm1, big.NewInt(1))
+	// floor((q-1)/2).
+	ub := new(big.Int).Rsh(qm1, 1)
+	// -ceil((q-1)/2) = floor(-(q-1)/2).
+	lb := new(big.Int).Neg(qm1)
+	lb.Rsh(lb, 1)
+	return lb, ub
+}
+
+// pkBounds returns the range [-ceil((q-1)/2), floor((q-1)/2)) of the uniform component
+// of the public key.
+func pkBounds(p *params.Params) (*big.Int, *big.Int) {
+	return coeffBounds(p)
+}
+
+// ekBounds returns the range [-ceil((q-1)/2), floor((q-1)/2)] of the uniform components
+// of the evaluation key, with an exclusive upper bound.
+func ekBounds(p *params.Params) (*big.Int, *big.Int) {
+	lb, ub := coeffBounds(p)
+	return lb, ub.Add(ub, big.NewInt(1))
+}
+
+// newSeed samples a seed for the uniform components of a key from the oracle.
+func newSeed(o oracle.Randomizer) ([]byte, error) {
+	rn, err := o.RandInt(0, 256, oracle.SeedSize)
+	if err != nil {
+		return nil, err
 	}
-	kc.O, kc.Params = o, params
-	return kc, nil
+	seed := make([]byte, len(rn))
+	for i := 0; i < len(rn); i++ {
+		seed[i] = byte(rn[i].Int64())
+	}
+	return seed, nil
 }
 
-// marshal encodes the keychain into a slice of bytes and stores it in a file.
-func (kc *Keychain) marshal(filepath string) error {
-	// Buffer.
-	var buf bytes.Buffer
-	// Data to be marshalled.
-	ks := Keystorage{SK: kc.SK, PK: kc.PK, EK: kc.EK, Literal: kc.Params.Literal}
-	// Encoder.
-	enc := gob.NewEncoder(&buf)
-	if err := enc.Encode(ks); err != nil {
-		return err
+// expandPK expands the seed into the uniform component of the public key.
+func expandPK(seed []byte, p *params.Params) ([]*big.Int, error) {
+	s, err := oracle.NewSeeded(seed)
+	if err != nil {
+		return nil, err
 	}
-	// Create output file.
-	outFile, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE, 0766)
+	lb, ub := pkBounds(p)
+	return s.RandBigInt(lb, ub, p.Size())
+}
+
+// expandEK expands the seed into the uniform components of the evaluation key, one
+// for each digit of the expansion of q in base w.
+func expandEK(seed []byte, p *params.Params) ([][]*big.Int, error) {
+	s, err := oracle.NewSeeded(seed)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// Write to output file.
-	w := bufio.NewWriter(outFile)
-	if _, err := w.Write(buf.Bytes()); err != nil {
-		return err
+	lb, ub := ekBounds(p)
+	as := make([][]*big.Int, p.RelinearizationDigits())
+	for i := 0; i < len(as); i++ {
+		if as[i], err = s.RandBigInt(lb, ub, p.Size()); err != nil {
+			return nil, err
+		}
 	}
-	w.Flush()
-	// Close output file.
-	if err := outFile.Close(); err != nil {
-		return err
+	return as, nil
+}
+
+// compress drops the uniform components of the keys that have a seed.
+func (ks *Keystorage) compress() {
+	if ks.PKSeed != nil && len(ks.PK) == 2 {
+		ks.PK = ks.PK[:1]
+	}
+	if ks.EKSeed != nil {
+		ek := make([][][]*big.Int, len(ks.EK))
+		for i := 0; i < len(ek); i++ {
+			ek[i] = ks.EK[i][:1]
+		}
+		ks.EK = ek
 	}
-	return nil
 }
 
-// unmarshal decodes the keychain from a slice of bytes previously stored in a file.
-func (kc *Keychain) unmarshal(filepath string) error {
-	// Buffer.
-	var buf []byte
-	// Open input file.
-	inFile, err := os.OpenFile(filepath, os.O_RDONLY, 0555)
-	if err != nil {
-		return err
-	}
-	// Read from input file.
-	r := bufio.NewReader(inFile)
-	if _, err := r.Read(buf); err != nil {
-		return err
-	}
-	// Instantiate a new Keystorage.
-	ks := new(Keystorage)
-	// Decoder.
-	dec := gob.NewDecoder(r)
-	if err := dec.Decode(&ks); err != nil {
-		return err
-	}
-	// Close output file.
-	if err := inFile.Close(); err != nil {
-		return err
-	}
-	// Fill the new Keychain with data read from the Keystorage.
-	kc.SK = ks.SK
-	kc.PK = ks.PK
-	kc.EK = ks.EK
-	p, err := params.New(ks.Literal)
-	if err != nil {
-		return err
+// expand restores the uniform components of the keys that have a seed.
+func (ks *Keystorage) expand(p *params.Params) error {
+	if ks.PKSeed != nil {
+		if len(ks.PK) != 1 {
+			return ErrKeyIsNotValid
+		}
+		a, err := expandPK(ks.PKSeed, p)
+		if err != nil {
+			return err
+		}
+		ks.PK = append(ks.PK, a)
+	}
+	if ks.EKSeed != nil {
+		as, err := expandEK(ks.EKSeed, p)
+		if err != nil {
+			return err
+		}
+		if len(ks.EK) != len(as) {
+			return ErrKeyIsNotValid
+		}
+		for i := 0; i < len(as); i++ {
+			if len(ks.EK[i]) != 1 {
+				return ErrKeyIsNotValid
+			}
+			ks.EK[i] = append(ks.EK[i], as[i])
+		}
 	}
-	kc.Params = p
 	return nil
 }
+
+// Setup returns the stored keys or creates new ones in the directory defined by the Dir
+// constant. It never overwrites an existing key file (see SetupKeyStore).
+func Setup(filename string, o oracle.Randomizer, params *params.Params) (*Keychain, error) {
+	return SetupKeyStore(NewFileKeyStore(Dir), filename, o, params, false)
+}
@@ -297,16 +297,16 @@ func equalKeychain(kc1, kc2 *Keychain) error {
 		return errors.New(fmt.Sprintf("expected value %d but got %d", kc1.Params.Literal.ExpansionBase, kc2.Params.Literal.ExpansionBase))
 	}
 	// Coefficient modulus.
-	if kc1.Params.Literal.CoefficientModulus != kc2.Params.Literal.CoefficientModulus {
-		return errors.New(fmt.Sprintf("expected value %d but got %d", kc1.Params.Literal.CoefficientModulus, kc2.Params.Literal.CoefficientModulus))
+	if kc1.Params.Literal.CoefficientModulus.Cmp(kc2.Params.Literal.CoefficientModulus) != 0 {
+		return errors.New(fmt.Sprintf("expected value %s but got %s", kc1.Params.Literal.CoefficientModulus, kc2.Params.Literal.CoefficientModulus))
 	}
 	// Decryption modulus.
-	if kc1.Params.Literal.DecryptionModulus != kc2.Params.Literal.DecryptionModulus {
-		return errors.New(fmt.Sprintf("expected value %d but got %d", kc1.Params.Literal.DecryptionModulus, kc2.Params.Literal.DecryptionModulus))
+	if kc1.Params.Literal.DecryptionModulus.Cmp(kc2.Params.Literal.DecryptionModulus) != 0 {
+		return errors.New(fmt.Sprintf("expected value %s but got %s", kc1.Params.Literal.DecryptionModulus, kc2.Params.Literal.DecryptionModulus))
 	}
 	// Relinearization expansion base.
-	if kc1.Params.Literal.RelinearizationExpansionBase != kc2.Params.Literal.RelinearizationExpansionBase {
-		return errors.New(fmt.Sprintf("expected value %d but got %d", kc1.Params.Literal.RelinearizationExpansionBase, kc2.Params.Literal.RelinearizationExpansionBase))
+	if kc1.Params.Literal.RelinearizationExpansionBase.Cmp(kc2.Params.Literal.RelinearizationExpansionBase) != 0 {
+		return errors.New(fmt.Sprintf("expected value %s but got %s", kc1.Params.Literal.RelinearizationExpansionBase, kc2.Params.Literal.RelinearizationExpansionBase))
 	}
 	// Standard deviation.
 	if kc1.Params.Literal.StandardDeviation != kc2.Params.Literal.StandardDeviation {
@@ -401,3 +401,31 @@ func TestSetupKeychain(t *testing.T) {
 		t.Errorf(err.Error())
 	}
 }
+
+// TestUnmarshalLegacyKeychain tests if keychains stored while the moduli were int64
+// values are still restored.
+func TestUnmarshalLegacyKeychain(t *testing.T) {
+	// Case: the stored PLHERatio16 keychain predates the *big.Int moduli.
+	// Filename for testing.
+	filepath := Dir + "PLHERatio16.kc"
+	// Restore keychain.
+	kc := new(Keychain)
+	if err := kc.unmarshal(filepath); err != nil {
+		t.Error(err)
+		return
+	}
+	// Compare parameters.
+	pl := params.PLHERatio16
+	if kc.Params.CoefficientModulus().Cmp(pl.CoefficientModulus) != 0 {
+		t.Errorf("expected coefficient modulus %s but got %s", pl.CoefficientModulus, kc.Params.CoefficientModulus())
+	}
+	if kc.Params.DecryptionModulus().Cmp(pl.DecryptionModulus) != 0 {
+		t.Errorf("expected decryption modulus %s but got %s", pl.DecryptionModulus, kc.Params.DecryptionModulus())
+	}
+	if kc.Params.RelinearizationExpansionBase().Cmp(pl.RelinearizationExpansionBase) != 0 {
+		t.Errorf("expected relinearization expansion base %s but got %s", pl.RelinearizationExpansionBase, kc.Params.RelinearizationExpansionBase())
+	}
+	if len(kc.SK) != kc.Params.Size() {
+		t.Errorf("expected secret key of size %d but got %d", kc.Params.Size(), len(kc.SK))
+	}
+}
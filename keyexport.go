@@ -0,0 +1,149 @@
+package scheme
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// Format of exported secret keys. All the fields are big-endian.
+//
+//	magic      [4]byte   "HRSK"
+//	version    uint8     sealedKeyVersion
+//	kdf        uint8     kdfPBKDF2SHA256
+//	iterations uint32    iterations of the KDF
+//	salt       [16]byte  salt of the KDF
+//	nonce      [12]byte  nonce of AES-GCM
+//	sealed     []byte    AES-256-GCM sealing of SecretKey.MarshalBinary
+//
+// The header, i.e., all the fields before sealed, is authenticated as additional
+// data, so that a change of the KDF parameters is detected as a wrong password.
+const (
+	sealedKeyVersion    = 1
+	kdfPBKDF2SHA256     = 1
+	saltSize            = 16
+	nonceSize           = 12
+	sealedKeyHeaderSize = 4 + 1 + 1 + 4 + saltSize + nonceSize
+)
+
+// KDFIterations is the number of PBKDF2-HMAC-SHA256 iterations of Export.
+const KDFIterations = 600000
+
+// maxKDFIterations is the largest number of iterations accepted by ImportSecretKey.
+// The number is read from the data before the password is checked, so it is kept
+// close to KDFIterations to bound the work that forged data can cause.
+const maxKDFIterations = 4 * KDFIterations
+
+// sealedKeyMagic identifies exported secret keys.
+var sealedKeyMagic = []byte("HRSK")
+
+// Export encrypts the secret key with a key derived from the password by
+// PBKDF2-HMAC-SHA256 with a random salt, and seals it with AES-256-GCM.
+func (sk *SecretKey) Export(password []byte) ([]byte, error) {
+	return sk.export(password, KDFIterations)
+}
+
+// export encrypts the secret key with the given number of KDF iterations.
+func (sk *SecretKey) export(password []byte, iterations int) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, ErrPasswordIsEmpty
+	}
+	if sk == nil || sk.Params == nil || !validSK(sk.Value, sk.Params) {
+		return nil, ErrKeyIsNotValid
+	}
+	data, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// Header with random salt and nonce.
+	header := make([]byte, sealedKeyHeaderSize)
+	copy(header, sealedKeyMagic)
+	header[4], header[5] = sealedKeyVersion, kdfPBKDF2SHA256
+	binary.BigEndian.PutUint32(header[6:10], uint32(iterations))
+	salt, nonce := header[10:10+saltSize], header[10+saltSize:]
+	if _, err := rand.Read(header[10:]); err != nil {
+		return nil, err
+	}
+	aead, err := newSealedKeyAEAD(password, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// ImportSecretKey decrypts a secret key exported by Export with the password.
+func ImportSecretKey(data, password []byte) (*SecretKey, error) {
+	if len(password) == 0 {
+		return nil, ErrPasswordIsEmpty
+	}
+	// Header.
+	if len(data) < sealedKeyHeaderSize || !bytes.Equal(data[:4], sealedKeyMagic) {
+		return nil, ErrSealedKeyIsNotValid
+	}
+	if data[4] != sealedKeyVersion || data[5] != kdfPBKDF2SHA256 {
+		return nil, ErrSealedKeyIsNotValid
+	}
+	iterations := int(binary.BigEndian.Uint32(data[6:10]))
+	if iterations < 1 || iterations > maxKDFIterations {
+		return nil, ErrSealedKeyIsNotValid
+	}
+	header := data[:sealedKeyHeaderSize]
+	salt, nonce := header[10:10+saltSize], header[10+saltSize:]
+	// Open.
+	aead, err := newSealedKeyAEAD(password, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, nonce, data[sealedKeyHeaderSize:], header)
+	if err != nil {
+		return nil, ErrPasswordIsWrong
+	}
+	sk := new(SecretKey)
+	if err := sk.UnmarshalBinary(plain); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// newSealedKeyAEAD returns AES-256-GCM keyed by PBKDF2-HMAC-SHA256 of the password.
+func newSealedKeyAEAD(password, salt []byte, iterations int) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(pbkdf2(sha256.New, password, salt, iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
+
+// pbkdf2 derives a key of the given length from the password as defined by
+// RFC 8018, with HMAC over the hash function h as the pseudorandom function.
+func pbkdf2(h func() hash.Hash, password, salt []byte, iterations, length int) []byte {
+	prf := hmac.New(h, password)
+	size := prf.Size()
+	dk := make([]byte, 0, (length+size-1)/size*size)
+	u := make([]byte, size)
+	t := make([]byte, size)
+	for block := uint32(1); len(dk) < length; block++ {
+		// U_1 = PRF(P, S || INT(i)).
+		prf.Reset()
+		prf.Write(salt)
+		binary.Write(prf, binary.BigEndian, block)
+		u = prf.Sum(u[:0])
+		copy(t, u)
+		// U_j = PRF(P, U_{j-1}), T_i = U_1 ^ ... ^ U_c.
+		for j := 1; j < iterations; j++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for k := range t {
+				t[k] ^= u[k]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:length]
+}
@@ -0,0 +1,100 @@
+package scheme
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+func TestPBKDF2(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		iterations     int
+		key            string
+	}{
+		// Case: vectors of RFC 7914, section 11.
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+		// Case: a key shorter than a block.
+		{"password", "salt", 4096, "c5e478d59288c841aa530db6845c4c8d962893a0"},
+	}
+	for _, tt := range tests {
+		key := pbkdf2(sha256.New, []byte(tt.password), []byte(tt.salt), tt.iterations, len(tt.key)/2)
+		if k := hex.EncodeToString(key); k != tt.key {
+			t.Errorf("expected key %s for %q but got %s", tt.key, tt.password, k)
+		}
+	}
+}
+
+func TestSecretKeyExport(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLHERatio16)
+	if err != nil {
+		t.Error(err)
+	}
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	password := []byte("correct horse battery staple")
+	// Case: the exported secret key is restored with the password.
+	data, err := kc.SecretKey().Export(password)
+	if err != nil {
+		t.Error(err)
+	}
+	sk, err := ImportSecretKey(data, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalPolys(sk.Value, kc.SK) || sk.Params.Fingerprint() != p.Fingerprint() {
+		t.Errorf("expected the secret key to be restored")
+	}
+	// Case: each export has its own salt and nonce.
+	data2, err := kc.SecretKey().export(password, 1000)
+	if err != nil {
+		t.Error(err)
+	}
+	if bytes.Equal(data[10:sealedKeyHeaderSize], data2[10:sealedKeyHeaderSize]) {
+		t.Errorf("expected different salts and nonces")
+	}
+	// modify returns a copy of the data changed by f.
+	modify := func(f func(d []byte) []byte) []byte {
+		d := append([]byte{}, data2...)
+		return f(d)
+	}
+	tests := []struct {
+		name     string
+		data     []byte
+		password []byte
+		err      error
+	}{
+		{"wrong password", data2, []byte("wrong"), ErrPasswordIsWrong},
+		{"empty password", data2, nil, ErrPasswordIsEmpty},
+		{"modified key", modify(func(d []byte) []byte { d[len(d)-1] ^= 1; return d }), password, ErrPasswordIsWrong},
+		{"modified iterations", modify(func(d []byte) []byte { d[9] ^= 1; return d }), password, ErrPasswordIsWrong},
+		{"modified salt", modify(func(d []byte) []byte { d[10] ^= 1; return d }), password, ErrPasswordIsWrong},
+		{"truncated", data2[:sealedKeyHeaderSize-1], password, ErrSealedKeyIsNotValid},
+		{"magic", modify(func(d []byte) []byte { d[0] = 'X'; return d }), password, ErrSealedKeyIsNotValid},
+		{"version", modify(func(d []byte) []byte { d[4] = 2; return d }), password, ErrSealedKeyIsNotValid},
+		{"kdf", modify(func(d []byte) []byte { d[5] = 2; return d }), password, ErrSealedKeyIsNotValid},
+		{"too many iterations", modify(func(d []byte) []byte { d[6] = 0xff; return d }), password, ErrSealedKeyIsNotValid},
+		{"iterations above the cap", modify(func(d []byte) []byte { binary.BigEndian.PutUint32(d[6:10], maxKDFIterations+1); return d }), password, ErrSealedKeyIsNotValid},
+	}
+	for _, tt := range tests {
+		if _, err := ImportSecretKey(tt.data, tt.password); err != tt.err {
+			t.Errorf("expected error %v for %s but got %v", tt.err, tt.name, err)
+		}
+	}
+	// Case: export errors.
+	if _, err := kc.SecretKey().Export(nil); err != ErrPasswordIsEmpty {
+		t.Errorf("expected error %s but got %v", ErrPasswordIsEmpty, err)
+	}
+	if _, err := (&SecretKey{Params: p}).Export(password); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+}
@@ -0,0 +1,169 @@
+package scheme
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// Key file format. All the fields are big-endian.
+//
+//	magic       [4]byte   "HRKC"
+//	version     uint8     keyFileVersion
+//	fingerprint uint64    fingerprint of the parameters
+//	length      uint64    length of the payload
+//	payload     []byte    gob encoding of the Keystorage
+//	checksum    [32]byte  SHA-256 of all the previous bytes
+//
+// Files without the magic are read as the gob encoding of a Keystorage that
+// earlier versions stored.
+const (
+	keyFileVersion    = 1
+	keyFileHeaderSize = 4 + 1 + 8 + 8
+)
+
+// keyFileMagic identifies key files.
+var keyFileMagic = []byte("HRKC")
+
+// MarshalBinary encodes the keychain into a versioned and checksummed key file.
+func (kc *Keychain) MarshalBinary() ([]byte, error) {
+	// Payload.
+	ks := Keystorage{SK: kc.SK, PK: kc.PK, EK: kc.EK, PKSeed: kc.PKSeed, EKSeed: kc.EKSeed, Literal: kc.Params.Literal}
+	ks.compress()
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(ks); err != nil {
+		return nil, err
+	}
+	// Header.
+	var buf bytes.Buffer
+	buf.Write(keyFileMagic)
+	buf.WriteByte(keyFileVersion)
+	binary.Write(&buf, binary.BigEndian, kc.Params.Fingerprint())
+	binary.Write(&buf, binary.BigEndian, uint64(payload.Len()))
+	buf.Write(payload.Bytes())
+	// Checksum.
+	sum := sha256.Sum256(buf.Bytes())
+	buf.Write(sum[:])
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a key file encoded by MarshalBinary, or stored by earlier
+// versions, and checks that the keys match their parameters.
+func (kc *Keychain) UnmarshalBinary(data []byte) error {
+	var ks *Keystorage
+	var fingerprint uint64
+	legacy := !bytes.HasPrefix(data, keyFileMagic)
+	if !legacy {
+		// Header.
+		if len(data) < keyFileHeaderSize+sha256.Size {
+			return ErrKeyFileIsNotValid
+		}
+		if data[4] != keyFileVersion {
+			return ErrKeyFileVersionIsNotValid
+		}
+		fingerprint = binary.BigEndian.Uint64(data[5:13])
+		if l := binary.BigEndian.Uint64(data[13:21]); l != uint64(len(data)-keyFileHeaderSize-sha256.Size) {
+			return ErrKeyFileIsNotValid
+		}
+		// Checksum.
+		end := len(data) - sha256.Size
+		if sum := sha256.Sum256(data[:end]); !bytes.Equal(sum[:], data[end:]) {
+			return ErrKeyFileChecksumMismatch
+		}
+		// Payload.
+		ks = new(Keystorage)
+		if err := gob.NewDecoder(bytes.NewReader(data[keyFileHeaderSize:end])).Decode(ks); err != nil {
+			return ErrKeyFileIsNotValid
+		}
+	} else {
+		var err error
+		if ks, err = migrateKeystorage(data); err != nil {
+			return err
+		}
+	}
+	p, err := params.New(ks.Literal)
+	if err != nil {
+		return err
+	}
+	if !legacy && fingerprint != p.Fingerprint() {
+		return ErrKeyIsNotValid
+	}
+	// Expand the uniform components from the seeds.
+	if err := ks.expand(p); err != nil {
+		return err
+	}
+	// The keys that are present must match the parameters.
+	if (ks.SK != nil && !validSK(ks.SK, p)) || (ks.PK != nil && !validPK(ks.PK, p)) || (ks.EK != nil && !validEK(ks.EK, p)) {
+		return ErrKeyIsNotValid
+	}
+	kc.SK, kc.PK, kc.EK = ks.SK, ks.PK, ks.EK
+	kc.PKSeed, kc.EKSeed = ks.PKSeed, ks.EKSeed
+	kc.Params = p
+	return nil
+}
+
+// migrateKeystorage decodes the gob encoding of a Keystorage stored by earlier
+// versions, including those whose moduli were int64 values.
+func migrateKeystorage(data []byte) (*Keystorage, error) {
+	ks := new(Keystorage)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(ks); err != nil {
+		lks := new(legacyKeystorage)
+		if lerr := gob.NewDecoder(bytes.NewReader(data)).Decode(lks); lerr != nil {
+			return nil, ErrKeyFileIsNotValid
+		}
+		ks = lks.keystorage()
+	}
+	return ks, nil
+}
+
+// marshal stores the keychain in a key file. The file is written to a temporary
+// file in the same directory, which then replaces it, so that it is never left
+// partially written.
+func (kc *Keychain) marshal(path string) error {
+	data, err := kc.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, true)
+}
+
+// unmarshal restores the keychain from a key file.
+func (kc *Keychain) unmarshal(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return kc.UnmarshalBinary(data)
+}
+
+// writeFileAtomic writes the data to a temporary file that is renamed to path. Unless
+// overwrite is set, the temporary file is linked to path instead, which fails with
+// fs.ErrExist if there is already a file, even one created concurrently.
+func writeFileAtomic(path string, data []byte, overwrite bool) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	// Remove the temporary file unless it was renamed, or once it is linked.
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if !overwrite {
+		return os.Link(f.Name(), path)
+	}
+	return os.Rename(f.Name(), path)
+}
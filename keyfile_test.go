@@ -0,0 +1,118 @@
+package scheme
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// resum recomputes the checksum of a key file.
+func resum(data []byte) []byte {
+	end := len(data) - sha256.Size
+	sum := sha256.Sum256(data[:end])
+	copy(data[end:], sum[:])
+	return data
+}
+
+func TestKeyFile(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	// New keychain.
+	kc1, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: a shorter file replaces a longer one completely.
+	path := filepath.Join(t.TempDir(), "PLBFV32.kc")
+	if err := os.WriteFile(path, bytes.Repeat([]byte{1}, 1<<16), 0600); err != nil {
+		t.Error(err)
+	}
+	if err := kc1.marshal(path); err != nil {
+		t.Error(err)
+	}
+	kc2 := new(Keychain)
+	if err := kc2.unmarshal(path); err != nil {
+		t.Error(err)
+	}
+	if err := equalKeychain(kc1, kc2); err != nil {
+		t.Error(err)
+	}
+	// Case: no temporary file is left.
+	if files, err := os.ReadDir(filepath.Dir(path)); err != nil || len(files) != 1 {
+		t.Errorf("expected only the key file but got %d files (%v)", len(files), err)
+	}
+	// Case: files stored by earlier versions are migrated.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(Keystorage{SK: kc1.SK, PK: kc1.PK, EK: kc1.EK, Literal: p.Literal}); err != nil {
+		t.Error(err)
+	}
+	kc3 := new(Keychain)
+	if err := kc3.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Error(err)
+	}
+	if err := equalKeychain(kc1, kc3); err != nil {
+		t.Error(err)
+	}
+	for _, filename := range []string{"PLHERatio16.kc", "PLBFV1024.kc"} {
+		if err := new(Keychain).unmarshal(Dir + filename); err != nil {
+			t.Errorf("expected %s to be migrated but got %v", filename, err)
+		}
+	}
+}
+
+func TestKeyFileErrors(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	// New keychain.
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	data, err := kc.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	// A key file whose secret key is too short.
+	short := &Keychain{SK: kc.SK[:p.Size()-1], Params: p}
+	sd, err := short.MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	// modify returns a copy of the data changed by f.
+	modify := func(f func(d []byte) []byte) []byte {
+		d := append([]byte{}, data...)
+		return f(d)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, ErrKeyFileIsNotValid},
+		{"garbage", []byte("garbage"), ErrKeyFileIsNotValid},
+		{"truncated", data[:keyFileHeaderSize], ErrKeyFileIsNotValid},
+		{"length", data[:len(data)-1], ErrKeyFileIsNotValid},
+		{"version", modify(func(d []byte) []byte { d[4] = 2; return d }), ErrKeyFileVersionIsNotValid},
+		{"checksum", modify(func(d []byte) []byte { d[keyFileHeaderSize] ^= 1; return d }), ErrKeyFileChecksumMismatch},
+		{"fingerprint", modify(func(d []byte) []byte { binary.BigEndian.PutUint64(d[5:13], 1); return resum(d) }), ErrKeyIsNotValid},
+		{"key length", sd, ErrKeyIsNotValid},
+	}
+	for _, tt := range tests {
+		if err := new(Keychain).UnmarshalBinary(tt.data); err != tt.err {
+			t.Errorf("expected error %v for %s but got %v", tt.err, tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package scheme
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/big"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// SecretKey is the ternary polynomial s, which is kept by the data owner to decrypt.
+type SecretKey struct {
+	Value  []*big.Int     // Coefficients of s.
+	Params *params.Params // Parameters.
+}
+
+// PublicKey is the pair (-a*s + e, a), which is given to the clients to encrypt.
+type PublicKey struct {
+	Value  [][]*big.Int   // Components of the key.
+	Seed   []byte         // Seed of a, if it is expanded from one.
+	Params *params.Params // Parameters.
+}
+
+// EvaluationKey holds the pairs (-a_i*s + e_i + w^i*s^2, a_i) for each digit of the
+// expansion of q in base w, which are given to the compute server to relinearize.
+type EvaluationKey struct {
+	Value  [][][]*big.Int // Pairs of components of the key.
+	Seed   []byte         // Seed of the a_i, if they are expanded from one.
+	Params *params.Params // Parameters.
+}
+
+// SecretKey returns the secret key of the keychain.
+func (kc *Keychain) SecretKey() *SecretKey {
+	return &SecretKey{Value: kc.SK, Params: kc.Params}
+}
+
+// PublicKey returns the public key of the keychain.
+func (kc *Keychain) PublicKey() *PublicKey {
+	return &PublicKey{Value: kc.PK, Seed: kc.PKSeed, Params: kc.Params}
+}
+
+// EvaluationKey returns the evaluation key of the keychain.
+func (kc *Keychain) EvaluationKey() *EvaluationKey {
+	return &EvaluationKey{Value: kc.EK, Seed: kc.EKSeed, Params: kc.Params}
+}
+
+// MarshalBinary encodes the secret key with its parameters.
+func (sk *SecretKey) MarshalBinary() ([]byte, error) {
+	return marshalKey(&Keystorage{SK: sk.Value, Literal: sk.Params.Literal})
+}
+
+// UnmarshalBinary decodes a secret key encoded by MarshalBinary.
+func (sk *SecretKey) UnmarshalBinary(data []byte) error {
+	ks, p, err := unmarshalKey(data)
+	if err != nil {
+		return err
+	}
+	if !validSK(ks.SK, p) {
+		return ErrKeyIsNotValid
+	}
+	sk.Value, sk.Params = ks.SK, p
+	return nil
+}
+
+// MarshalBinary encodes the public key with its parameters. A key with a seed is
+// encoded with the seed in place of a.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return marshalKey(&Keystorage{PK: pk.Value, PKSeed: pk.Seed, Literal: pk.Params.Literal})
+}
+
+// UnmarshalBinary decodes a public key encoded by MarshalBinary.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	ks, p, err := unmarshalKey(data)
+	if err != nil {
+		return err
+	}
+	if !validPK(ks.PK, p) {
+		return ErrKeyIsNotValid
+	}
+	pk.Value, pk.Seed, pk.Params = ks.PK, ks.PKSeed, p
+	return nil
+}
+
+// MarshalBinary encodes the evaluation key with its parameters. A key with a seed is
+// encoded with the seed in place of the a_i.
+func (ek *EvaluationKey) MarshalBinary() ([]byte, error) {
+	return marshalKey(&Keystorage{EK: ek.Value, EKSeed: ek.Seed, Literal: ek.Params.Literal})
+}
+
+// UnmarshalBinary decodes an evaluation key encoded by MarshalBinary.
+func (ek *EvaluationKey) UnmarshalBinary(data []byte) error {
+	ks, p, err := unmarshalKey(data)
+	if err != nil {
+		return err
+	}
+	if !validEK(ks.EK, p) {
+		return ErrKeyIsNotValid
+	}
+	ek.Value, ek.Seed, ek.Params = ks.EK, ks.EKSeed, p
+	return nil
+}
+
+// marshalKey encodes a Keystorage that holds a single key.
+func marshalKey(ks *Keystorage) ([]byte, error) {
+	ks.compress()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ks); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalKey decodes a Keystorage that holds a single key, validates its parameters
+// and expands the uniform components from the seed.
+func unmarshalKey(data []byte) (*Keystorage, *params.Params, error) {
+	ks := new(Keystorage)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(ks); err != nil {
+		return nil, nil, err
+	}
+	p, err := params.New(ks.Literal)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ks.expand(p); err != nil {
+		return nil, nil, err
+	}
+	return ks, p, nil
+}
+
+// validSK reports whether the secret key has the size of the parameters.
+func validSK(sk []*big.Int, p *params.Params) bool {
+	return len(sk) == p.Size()
+}
+
+// validPK reports whether the public key has two components of the size of the parameters.
+func validPK(pk [][]*big.Int, p *params.Params) bool {
+	return len(pk) == 2 && len(pk[0]) == p.Size() && len(pk[1]) == p.Size()
+}
+
+// validEK reports whether the evaluation key has one pair of components of the size
+// of the parameters per digit of the expansion of q in base w.
+func validEK(ek [][][]*big.Int, p *params.Params) bool {
+	if len(ek) != p.RelinearizationDigits() {
+		return false
+	}
+	for i := 0; i < len(ek); i++ {
+		if !validPK(ek[i], p) {
+			return false
+		}
+	}
+	return true
+}
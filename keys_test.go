@@ -0,0 +1,238 @@
+package scheme
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// equalPolys reports whether two slices of coefficients are equal.
+func equalPolys(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKeySerialization(t *testing.T) {
+	for _, pl := range []params.Literal{params.PLHERatio16, params.PLBFV32} {
+		// Parameters.
+		p, err := params.New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		// Keychain.
+		kc, err := NewKeychain(new(oracle.Oracle), p)
+		if err != nil {
+			t.Error(err)
+		}
+		// Case: secret key.
+		data, err := kc.SecretKey().MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		sk := new(SecretKey)
+		if err := sk.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(sk.Value, kc.SK) || sk.Params.Fingerprint() != p.Fingerprint() {
+			t.Errorf("expected the secret key to be restored")
+		}
+		// Case: public key.
+		data, err = kc.PublicKey().MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		pk := new(PublicKey)
+		if err := pk.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+		}
+		if !equalPolys(pk.Value[0], kc.PK[0]) || !equalPolys(pk.Value[1], kc.PK[1]) || !bytes.Equal(pk.Seed, kc.PKSeed) || pk.Params.Fingerprint() != p.Fingerprint() {
+			t.Errorf("expected the public key to be restored")
+		}
+		// Case: a public key is not a secret key.
+		if err := new(SecretKey).UnmarshalBinary(data); err != ErrKeyIsNotValid {
+			t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+		}
+		// Case: evaluation key.
+		data, err = kc.EvaluationKey().MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		ek := new(EvaluationKey)
+		if err := ek.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+		}
+		if len(ek.Value) != len(kc.EK) || !bytes.Equal(ek.Seed, kc.EKSeed) {
+			t.Fatalf("expected %d pairs in the evaluation key but got %d", len(kc.EK), len(ek.Value))
+		}
+		for i := 0; i < len(ek.Value); i++ {
+			if !equalPolys(ek.Value[i][0], kc.EK[i][0]) || !equalPolys(ek.Value[i][1], kc.EK[i][1]) {
+				t.Errorf("expected pair [%d] of the evaluation key to be restored", i)
+			}
+		}
+		// Case: an evaluation key is not a public key.
+		if err := new(PublicKey).UnmarshalBinary(data); err != ErrKeyIsNotValid {
+			t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+		}
+	}
+}
+
+func TestSeparateKeys(t *testing.T) {
+	// Create parameters with q = 2^256 - 189, whose noise budget supports a multiplication.
+	pl := params.PLHERatio16
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	pl.CoefficientModulus.Sub(pl.CoefficientModulus, big.NewInt(189))
+	p, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	// The data owner generates the keys and distributes them serialized.
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	skb, err := kc.SecretKey().MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	pkb, err := kc.PublicKey().MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	ekb, err := kc.EvaluationKey().MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	sk, pk, ek := new(SecretKey), new(PublicKey), new(EvaluationKey)
+	if err := sk.UnmarshalBinary(skb); err != nil {
+		t.Error(err)
+	}
+	if err := pk.UnmarshalBinary(pkb); err != nil {
+		t.Error(err)
+	}
+	if err := ek.UnmarshalBinary(ekb); err != nil {
+		t.Error(err)
+	}
+	// The client encrypts with the public key.
+	enc, err := NewEncryptor(pk, new(oracle.Oracle))
+	if err != nil {
+		t.Error(err)
+	}
+	lc := laurent.New(pk.Params)
+	c0, err := enc.Enc(NewPlaintext(lc.Enc(params.M0), CodecLaurent, pk.Params))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := enc.Enc(NewPlaintext(lc.Enc(params.M1), CodecLaurent, pk.Params))
+	if err != nil {
+		t.Error(err)
+	}
+	// The server multiplies with the evaluation key.
+	cr, err := NewEvaluatorFromKey(ek).Mult(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	// The data owner decrypts with the secret key.
+	dec, err := NewDecryptor(sk)
+	if err != nil {
+		t.Error(err)
+	}
+	mr, err := dec.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	// Check result.
+	if r, er := lc.Dec(mr.Value), params.M0*params.M1; math.Abs(r-er) > 1e-9*math.Abs(er) {
+		t.Errorf("expected %f for %f x %f, but got %f", er, params.M0, params.M1, r)
+	}
+
+	// Case: missing keys.
+	if _, err := NewEncryptor(nil, new(oracle.Oracle)); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+	if _, err := NewDecryptor(&SecretKey{Params: p}); err != ErrKeyIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrKeyIsNotValid, err)
+	}
+}
+
+func TestSeededKeys(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	// Keychain.
+	kc, err := NewKeychain(new(oracle.Oracle), p)
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: the uniform components are expanded from the seeds.
+	a, err := expandPK(kc.PKSeed, p)
+	if err != nil {
+		t.Error(err)
+	}
+	if !equalPolys(a, kc.PK[1]) {
+		t.Errorf("expected the uniform component of the public key to be expanded from the seed")
+	}
+	as, err := expandEK(kc.EKSeed, p)
+	if err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < len(as); i++ {
+		if !equalPolys(as[i], kc.EK[i][1]) {
+			t.Errorf("expected the uniform component [%d] of the evaluation key to be expanded from the seed", i)
+		}
+	}
+	// Case: keys with a seed are encoded without their uniform components, which
+	// take at least a byte per coefficient.
+	for _, k := range []struct {
+		seeded, full interface{ MarshalBinary() ([]byte, error) }
+		uniform      int
+	}{
+		{kc.PublicKey(), &PublicKey{Value: kc.PK, Params: p}, 1},
+		{kc.EvaluationKey(), &EvaluationKey{Value: kc.EK, Params: p}, len(kc.EK)},
+	} {
+		sd, err := k.seeded.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		fd, err := k.full.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+		}
+		if len(fd)-len(sd) < k.uniform*p.Size() {
+			t.Errorf("expected at most %d bytes for the seeded key but got %d", len(fd)-k.uniform*p.Size(), len(sd))
+		}
+	}
+	// Case: a full public key is still decoded.
+	data, err := (&PublicKey{Value: kc.PK, Params: p}).MarshalBinary()
+	if err != nil {
+		t.Error(err)
+	}
+	pk := new(PublicKey)
+	if err := pk.UnmarshalBinary(data); err != nil {
+		t.Error(err)
+	}
+	if !equalPolys(pk.Value[1], kc.PK[1]) || pk.Seed != nil {
+		t.Errorf("expected the full public key to be restored")
+	}
+	// Case: an invalid seed.
+	data, err = marshalKey(&Keystorage{PK: kc.PK, PKSeed: []byte{1}, Literal: p.Literal})
+	if err != nil {
+		t.Error(err)
+	}
+	if err := new(PublicKey).UnmarshalBinary(data); err != oracle.ErrSeedIsNotValid {
+		t.Errorf("expected error %s but got %v", oracle.ErrSeedIsNotValid, err)
+	}
+}
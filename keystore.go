@@ -0,0 +1,163 @@
+package scheme
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// KeyStore stores key files by name.
+type KeyStore interface {
+	// Load returns the key file stored under the name, or ErrKeyNotFound.
+	Load(name string) ([]byte, error)
+	// Store stores the key file under the name. It returns ErrKeyExists if there
+	// is already a key file under the name, unless overwrite is set.
+	Store(name string, data []byte, overwrite bool) error
+}
+
+// FileKeyStore stores key files in a directory.
+type FileKeyStore struct {
+	root string
+}
+
+// NewFileKeyStore creates a FileKeyStore for the directory root, which is created
+// when the first key file is stored.
+func NewFileKeyStore(root string) *FileKeyStore {
+	return &FileKeyStore{root: root}
+}
+
+// Load returns the key file stored under the name.
+func (fks *FileKeyStore) Load(name string) ([]byte, error) {
+	path, err := fks.path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrKeyNotFound
+	}
+	return data, err
+}
+
+// Store writes the key file atomically under the name. Without overwrite, an
+// existing key file is never replaced, even if it is created concurrently.
+func (fks *FileKeyStore) Store(name string, data []byte, overwrite bool) error {
+	path, err := fks.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(fks.root, 0700); err != nil {
+		return err
+	}
+	err = writeFileAtomic(path, data, overwrite)
+	if errors.Is(err, fs.ErrExist) {
+		return ErrKeyExists
+	}
+	return err
+}
+
+// path returns the path of the key file, which must be in the root directory.
+func (fks *FileKeyStore) path(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", ErrKeyNameIsNotValid
+	}
+	return filepath.Join(fks.root, name), nil
+}
+
+// MemoryKeyStore stores key files in memory.
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+// NewMemoryKeyStore creates an empty MemoryKeyStore.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{data: make(map[string][]byte)}
+}
+
+// Load returns a copy of the key file stored under the name.
+func (mks *MemoryKeyStore) Load(name string) ([]byte, error) {
+	mks.mu.Lock()
+	defer mks.mu.Unlock()
+	data, ok := mks.data[name]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte{}, data...), nil
+}
+
+// Store stores a copy of the key file under the name.
+func (mks *MemoryKeyStore) Store(name string, data []byte, overwrite bool) error {
+	mks.mu.Lock()
+	defer mks.mu.Unlock()
+	if _, ok := mks.data[name]; ok && !overwrite {
+		return ErrKeyExists
+	}
+	mks.data[name] = append([]byte{}, data...)
+	return nil
+}
+
+// ReadOnlyKeyStore loads key files from another KeyStore and refuses to store them.
+type ReadOnlyKeyStore struct {
+	ks KeyStore
+}
+
+// NewReadOnlyKeyStore creates a read-only view of the key store.
+func NewReadOnlyKeyStore(ks KeyStore) *ReadOnlyKeyStore {
+	return &ReadOnlyKeyStore{ks: ks}
+}
+
+// Load returns the key file stored under the name.
+func (roks *ReadOnlyKeyStore) Load(name string) ([]byte, error) {
+	return roks.ks.Load(name)
+}
+
+// Store returns ErrKeyStoreIsReadOnly.
+func (roks *ReadOnlyKeyStore) Store(name string, data []byte, overwrite bool) error {
+	return ErrKeyStoreIsReadOnly
+}
+
+// SetupKeyStore returns the keychain stored under the name in the key store, or
+// creates and stores a new one when there is none. A key file that cannot be
+// decoded, or that holds keys for other parameters, is an error, and it is only
+// replaced by a new keychain when overwrite is set. If the new keychain cannot be
+// stored, e.g., because another one was stored concurrently, it is discarded.
+func SetupKeyStore(ks KeyStore, name string, o oracle.Randomizer, p *params.Params, overwrite bool) (*Keychain, error) {
+	// Try to restore keychain.
+	data, err := ks.Load(name)
+	switch {
+	case err == nil:
+		kc := new(Keychain)
+		if err = kc.UnmarshalBinary(data); err == nil && kc.Params.Fingerprint() != p.Fingerprint() {
+			err = ErrParametersMismatch
+		}
+		if err == nil {
+			kc.O, kc.Params = o, p
+			return kc, nil
+		}
+		if !overwrite {
+			return nil, err
+		}
+	case !errors.Is(err, ErrKeyNotFound):
+		return nil, err
+	}
+	// Create a new keychain.
+	kc, err := NewKeychain(o, p)
+	if err != nil {
+		return nil, err
+	}
+	// Store keychain.
+	if data, err = kc.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if err = ks.Store(name, data, overwrite); err != nil {
+		return nil, err
+	}
+	return kc, nil
+}
@@ -0,0 +1,154 @@
+package scheme
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+func TestKeyStore(t *testing.T) {
+	stores := []struct {
+		name string
+		ks   KeyStore
+	}{
+		{"file", NewFileKeyStore(filepath.Join(t.TempDir(), "keys"))},
+		{"memory", NewMemoryKeyStore()},
+	}
+	for _, s := range stores {
+		// Case: missing key file.
+		if _, err := s.ks.Load("a.kc"); err != ErrKeyNotFound {
+			t.Errorf("expected error %s for %s but got %v", ErrKeyNotFound, s.name, err)
+		}
+		// Case: stored key file.
+		if err := s.ks.Store("a.kc", []byte{1, 2, 3}, false); err != nil {
+			t.Error(err)
+		}
+		if data, err := s.ks.Load("a.kc"); err != nil || !bytes.Equal(data, []byte{1, 2, 3}) {
+			t.Errorf("expected the key file to be loaded for %s but got %v (%v)", s.name, data, err)
+		}
+		// Case: existing key files are only overwritten with permission.
+		if err := s.ks.Store("a.kc", []byte{4}, false); err != ErrKeyExists {
+			t.Errorf("expected error %s for %s but got %v", ErrKeyExists, s.name, err)
+		}
+		if err := s.ks.Store("a.kc", []byte{4}, true); err != nil {
+			t.Error(err)
+		}
+		if data, err := s.ks.Load("a.kc"); err != nil || !bytes.Equal(data, []byte{4}) {
+			t.Errorf("expected the key file to be overwritten for %s but got %v (%v)", s.name, data, err)
+		}
+		// Case: read-only view.
+		ro := NewReadOnlyKeyStore(s.ks)
+		if data, err := ro.Load("a.kc"); err != nil || !bytes.Equal(data, []byte{4}) {
+			t.Errorf("expected the key file to be loaded for %s but got %v (%v)", s.name, data, err)
+		}
+		if err := ro.Store("b.kc", []byte{5}, true); err != ErrKeyStoreIsReadOnly {
+			t.Errorf("expected error %s for %s but got %v", ErrKeyStoreIsReadOnly, s.name, err)
+		}
+	}
+	// Case: of concurrent stores without overwrite, exactly one succeeds, and no
+	// temporary file is left behind.
+	root := t.TempDir()
+	fks := NewFileKeyStore(root)
+	var wg sync.WaitGroup
+	errs := make([]error, 16)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fks.Store("c.kc", []byte{byte(i)}, false)
+		}(i)
+	}
+	wg.Wait()
+	stored := -1
+	for i, err := range errs {
+		switch err {
+		case nil:
+			if stored >= 0 {
+				t.Errorf("expected a single store but got %d and %d", stored, i)
+			}
+			stored = i
+		case ErrKeyExists:
+		default:
+			t.Error(err)
+		}
+	}
+	if data, err := fks.Load("c.kc"); err != nil || !bytes.Equal(data, []byte{byte(stored)}) {
+		t.Errorf("expected the key file of store %d but got %v (%v)", stored, data, err)
+	}
+	if entries, err := os.ReadDir(root); err != nil || len(entries) != 1 {
+		t.Errorf("expected a single file but got %d (%v)", len(entries), err)
+	}
+	// Case: names outside the root directory.
+	for _, name := range []string{"", "..", "../a.kc", "keys/a.kc"} {
+		if err := fks.Store(name, []byte{1}, true); err != ErrKeyNameIsNotValid {
+			t.Errorf("expected error %s for %q but got %v", ErrKeyNameIsNotValid, name, err)
+		}
+	}
+}
+
+func TestSetupKeyStore(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	o := new(oracle.Oracle)
+	ks := NewMemoryKeyStore()
+	// Case: a new keychain is created and stored.
+	kc1, err := SetupKeyStore(ks, "PLBFV32.kc", o, p, false)
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: the stored keychain is restored.
+	kc2, err := SetupKeyStore(ks, "PLBFV32.kc", o, p, false)
+	if err != nil {
+		t.Error(err)
+	}
+	if err := equalKeychain(kc1, kc2); err != nil {
+		t.Error(err)
+	}
+	// Case: a corrupt key file is an error and is kept.
+	data, _ := ks.Load("PLBFV32.kc")
+	data[len(data)/2] ^= 1
+	if err := ks.Store("PLBFV32.kc", data, true); err != nil {
+		t.Error(err)
+	}
+	if _, err := SetupKeyStore(ks, "PLBFV32.kc", o, p, false); err != ErrKeyFileChecksumMismatch {
+		t.Errorf("expected error %s but got %v", ErrKeyFileChecksumMismatch, err)
+	}
+	if kept, _ := ks.Load("PLBFV32.kc"); !bytes.Equal(kept, data) {
+		t.Errorf("expected the corrupt key file to be kept")
+	}
+	// Case: keys for other parameters are an error.
+	ph, err := params.New(params.PLHERatio16)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := SetupKeyStore(ks, "PLBFV32.kc", o, p, true); err != nil {
+		t.Error(err)
+	}
+	if _, err := SetupKeyStore(ks, "PLBFV32.kc", o, ph, false); err != ErrParametersMismatch {
+		t.Errorf("expected error %s but got %v", ErrParametersMismatch, err)
+	}
+	// Case: with permission, the key file is replaced by a new keychain.
+	kc3, err := SetupKeyStore(ks, "PLBFV32.kc", o, ph, true)
+	if err != nil {
+		t.Error(err)
+	}
+	kc4, err := SetupKeyStore(ks, "PLBFV32.kc", o, ph, false)
+	if err != nil {
+		t.Error(err)
+	}
+	if err := equalKeychain(kc3, kc4); err != nil {
+		t.Error(err)
+	}
+	// Case: a read-only key store cannot store a new keychain.
+	if kc, err := SetupKeyStore(NewReadOnlyKeyStore(NewMemoryKeyStore()), "PLBFV32.kc", o, p, false); err != ErrKeyStoreIsReadOnly || kc != nil {
+		t.Errorf("expected error %s and no keychain but got %v", ErrKeyStoreIsReadOnly, err)
+	}
+}
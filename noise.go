@@ -0,0 +1,11 @@
+package scheme
+
+import "github.com/Algemetric/HERatio/Implementation/Golang/params"
+
+// Ciphertexts carry the worst-case and average-case estimates of their noise, which
+// every operation updates with the noise model of the parameters (see params.Noise).
+
+// estimateOf returns the noise estimate carried by a ciphertext.
+func estimateOf(ct *Ciphertext) params.Noise {
+	return params.Noise{Worst: ct.Noise, Avg: ct.AvgNoise}
+}
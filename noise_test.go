@@ -0,0 +1,127 @@
+package scheme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+func TestNoiseModel(t *testing.T) {
+	for _, ps := range testPresets {
+		p, cip, eval := newTestCipher(t, ps.pl)
+		// Raw messages, since the noise does not depend on the codec.
+		m := make([]*big.Int, p.Size())
+		for i := 0; i < len(m); i++ {
+			m[i] = big.NewInt(int64(i % 7))
+		}
+		c0, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		c1, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+		if err != nil {
+			t.Error(err)
+		}
+		ca, err := eval.Add(c0, c1)
+		if err != nil {
+			t.Error(err)
+		}
+		cs, err := eval.SMult(c0, big.NewInt(params.MS))
+		if err != nil {
+			t.Error(err)
+		}
+		cm, err := eval.Mult(c0, c1)
+		if err != nil {
+			t.Error(err)
+		}
+		for i, c := range []*Ciphertext{c0, ca, cs, cm} {
+			b, err := cip.NoiseBudget(c)
+			if err != nil {
+				t.Error(err)
+			}
+			// Noise measured with the secret key.
+			v := eval.ceiling - b
+			// Case: the average case is below the worst case.
+			if c.AvgNoise > c.Noise {
+				t.Errorf("expected average-case estimate below %f for %s at [%d] but got %f", c.Noise, ps.name, i, c.AvgNoise)
+			}
+			// Case: the estimates bound the measured noise. The budget is zero when the
+			// noise reaches the threshold, so only a lower bound is measured.
+			if c.Noise < v {
+				t.Errorf("expected worst-case estimate above %f for %s at [%d] but got %f", v, ps.name, i, c.Noise)
+			}
+			if c.AvgNoise < v {
+				t.Errorf("expected average-case estimate above %f for %s at [%d] but got %f", v, ps.name, i, c.AvgNoise)
+			}
+		}
+	}
+}
+
+func TestNoiseCheck(t *testing.T) {
+	// Parameters.
+	p, cip, eval := newTestCipher(t, params.PLBFV32)
+	m := make([]*big.Int, p.Size())
+	for i := 0; i < len(m); i++ {
+		m[i] = big.NewInt(1)
+	}
+	c0, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(NewPlaintext(m, CodecNone, p))
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: operations are not refused by default.
+	if _, err := eval.Mult(c0, c1); err != nil {
+		t.Error(err)
+	}
+	// Case: the worst-case estimate of the multiplication exceeds the threshold of PLBFV32.
+	eval.SetNoiseCheck(NoiseCheckWorst)
+	if _, err := eval.Mult(c0, c1); err != ErrNoiseBudgetExceeded {
+		t.Errorf("expected error %s but got %v", ErrNoiseBudgetExceeded, err)
+	}
+	if _, err := eval.Add(c0, c1); err != nil {
+		t.Error(err)
+	}
+	// Case: in-place operations leave the ciphertext unchanged when refused.
+	c2 := c0.Copy()
+	c2.Noise = eval.ceiling - 0.5
+	c3 := c2.Copy()
+	if err := eval.SubInPlace(c2, c3); err != ErrNoiseBudgetExceeded {
+		t.Errorf("expected error %s but got %v", ErrNoiseBudgetExceeded, err)
+	}
+	for i := 0; i < len(c2.Value); i++ {
+		for j := 0; j < len(c2.Value[i]); j++ {
+			if c2.Value[i][j].Cmp(c3.Value[i][j]) != 0 {
+				t.Errorf("expected %s for position [%d][%d] but got %s", c3.Value[i][j].String(), i, j, c2.Value[i][j].String())
+				break
+			}
+		}
+	}
+	if c2.Noise != c3.Noise {
+		t.Errorf("expected noise estimate %f but got %f", c3.Noise, c2.Noise)
+	}
+	// Case: the average-case estimate only refuses what the average case exceeds.
+	eval.SetNoiseCheck(NoiseCheckAverage)
+	if err := eval.SubInPlace(c2, c3); err != nil {
+		t.Error(err)
+	}
+	if _, err := eval.Mult(c0, c1); err != ErrNoiseBudgetExceeded {
+		t.Errorf("expected error %s but got %v", ErrNoiseBudgetExceeded, err)
+	}
+	// Case: parameters with a larger noise budget.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	pl.CoefficientModulus.Sub(pl.CoefficientModulus, big.NewInt(189))
+	pb, cb, eb := newTestCipher(t, pl)
+	eb.SetNoiseCheck(NoiseCheckWorst)
+	c4, err := cb.Enc(NewPlaintext(m, CodecNone, pb))
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := eb.Mult(c4, c4); err != nil {
+		t.Error(err)
+	}
+}
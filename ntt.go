@@ -0,0 +1,171 @@
+package scheme
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// nttTables caches the twiddle tables already computed for a given size and modulus.
+var nttTables sync.Map
+
+// nttKey identifies a twiddle table in the cache.
+type nttKey struct {
+	n int    // Transform size.
+	q string // Coefficient modulus (decimal).
+}
+
+// nttTable holds the precomputed twiddle factors of the negacyclic
+// number-theoretic transform for a given size and prime modulus.
+type nttTable struct {
+	n      int        // Transform size.
+	q      *big.Int   // Prime modulus.
+	psi    []*big.Int // Powers of a primitive 2n-th root of unity in bit-reversed order.
+	psiInv []*big.Int // Powers of the inverse root in bit-reversed order.
+	nInv   *big.Int   // Inverse of n modulo q.
+}
+
+// IsNTTFriendly reports whether the coefficient modulus of the parameters is a prime q
+// with q = 1 (mod 2n), where n is the size of the polynomials. Such a modulus allows
+// polynomial multiplication through the number-theoretic transform.
+func IsNTTFriendly(p *params.Params) bool {
+	return nttTableFor(p) != nil
+}
+
+// nttTableFor returns the twiddle table for the parameters, or nil if the
+// coefficient modulus is not NTT-friendly. Tables are computed once and cached.
+func nttTableFor(p *params.Params) *nttTable {
+	q := p.CoefficientModulus()
+	k := nttKey{n: p.Size(), q: q.String()}
+	if t, ok := nttTables.Load(k); ok {
+		return t.(*nttTable)
+	}
+	t := newNTTTable(p.Size(), q)
+	nttTables.Store(k, t)
+	return t
+}
+
+// newNTTTable computes the twiddle factors for size n and modulus q.
+// It returns nil if n is not a power of 2 or q is not an NTT-friendly prime.
+func newNTTTable(n int, q *big.Int) *nttTable {
+	// Size must be a power of 2.
+	if n < 2 || n&(n-1) != 0 {
+		return nil
+	}
+	// Modulus must be a prime.
+	if !q.ProbablyPrime(20) {
+		return nil
+	}
+	// Modulus must satisfy q = 1 (mod 2n).
+	qm1 := new(big.Int).Sub(q, big.NewInt(1))
+	e, r := new(big.Int).QuoRem(qm1, big.NewInt(int64(2*n)), new(big.Int))
+	if r.Sign() != 0 {
+		return nil
+	}
+	// Search for a primitive 2n-th root of unity psi, i.e., psi^n = -1 (mod q).
+	psi := new(big.Int)
+	pn := new(big.Int)
+	for g := int64(2); ; g++ {
+		psi.Exp(big.NewInt(g), e, q)
+		pn.Exp(psi, big.NewInt(int64(n)), q)
+		if pn.Cmp(qm1) == 0 {
+			break
+		}
+	}
+	psiInv := new(big.Int).ModInverse(psi, q)
+	// Powers of psi and its inverse in bit-reversed order.
+	t := &nttTable{n: n, q: q, psi: make([]*big.Int, n), psiInv: make([]*big.Int, n)}
+	logN := bits.Len(uint(n)) - 1
+	pw, pwInv := big.NewInt(1), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse(uint(i)) >> (bits.UintSize - logN))
+		t.psi[j] = new(big.Int).Set(pw)
+		t.psiInv[j] = new(big.Int).Set(pwInv)
+		pw.Mul(pw, psi).Mod(pw, q)
+		pwInv.Mul(pwInv, psiInv).Mod(pwInv, q)
+	}
+	t.nInv = new(big.Int).ModInverse(big.NewInt(int64(n)), q)
+	return t
+}
+
+// mod reduces z into [0, q), with the temporary quotient quo. Unlike big.Int.Mod,
+// it does not allocate a new quotient on every call.
+func (t *nttTable) mod(z, quo *big.Int) {
+	quo.QuoRem(z, t.q, z)
+	if z.Sign() < 0 {
+		z.Add(z, t.q)
+	}
+}
+
+// forward sets a to the transform of the coefficients x in the evaluation domain
+// (Cooley-Tukey), with the temporary integers v and quo. The vector a can be x.
+func (t *nttTable) forward(a, x []*big.Int, v, quo *big.Int) {
+	for i := 0; i < t.n; i++ {
+		t.mod(a[i].Set(x[i]), quo)
+	}
+	for m, h := 1, t.n/2; m < t.n; m, h = 2*m, h/2 {
+		for i := 0; i < m; i++ {
+			s := t.psi[m+i]
+			for j := 2 * i * h; j < 2*i*h+h; j++ {
+				t.mod(v.Mul(a[j+h], s), quo)
+				t.mod(a[j+h].Sub(a[j], v), quo)
+				t.mod(a[j].Add(a[j], v), quo)
+			}
+		}
+	}
+}
+
+// inverse transforms the values back into the coefficient domain in place
+// (Gentleman-Sande), with the temporary integers u and quo.
+func (t *nttTable) inverse(a []*big.Int, u, quo *big.Int) {
+	for m, h := t.n/2, 1; m >= 1; m, h = m/2, 2*h {
+		for i := 0; i < m; i++ {
+			s := t.psiInv[m+i]
+			for j := 2 * i * h; j < 2*i*h+h; j++ {
+				u.Set(a[j])
+				t.mod(a[j].Add(u, a[j+h]), quo)
+				t.mod(a[j+h].Sub(u, a[j+h]).Mul(a[j+h], s), quo)
+			}
+		}
+	}
+	for i := 0; i < t.n; i++ {
+		t.mod(a[i].Mul(a[i], t.nInv), quo)
+	}
+}
+
+// NTTPolyMult multiplies two polynomials through the number-theoretic transform.
+// The result is reduced modulo the coefficient modulus (symmetric representation),
+// which therefore must be NTT-friendly.
+func NTTPolyMult(x, y []*big.Int, p *params.Params) ([]*big.Int, error) {
+	t := nttTableFor(p)
+	if t == nil {
+		return nil, ErrModulusIsNotNTTFriendly
+	}
+	// Negacyclic convolution.
+	a, b, v, quo := newInts(t.n), newInts(t.n), new(big.Int), new(big.Int)
+	t.forward(a, x, v, quo)
+	t.forward(b, y, v, quo)
+	for i := 0; i < t.n; i++ {
+		t.mod(a[i].Mul(a[i], b[i]), quo)
+	}
+	t.inverse(a, v, quo)
+	c := VecSymMod(a, t.q)
+	switch p.Scheme() {
+	case params.BFV:
+		return c, nil
+	case params.HERatio:
+		// Laurent polynomials are stored with an offset of n in their powers,
+		// so the negacyclic product is multiplied by x^(-n) = -x^n.
+		n := p.Degree()
+		prod := make([]*big.Int, t.n)
+		for i := 0; i < n; i++ {
+			prod[i] = c[i+n]
+			prod[i+n] = c[i].Neg(c[i])
+		}
+		return prod, nil
+	default:
+		return nil, ErrSchemeIsNotValid
+	}
+}
@@ -0,0 +1,94 @@
+package scheme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// NTT-friendly prime (q = 1 mod 4096) close to the default coefficient modulus.
+const nttCoefficientModulus = 9_876_590_593
+
+func TestIsNTTFriendly(t *testing.T) {
+	// Case: default presets are not NTT-friendly.
+	for _, pl := range []params.Literal{params.PLHERatio16, params.PLBFV32, params.PLBFV2048} {
+		p, err := params.New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		if IsNTTFriendly(p) {
+			t.Errorf("coefficient modulus %d should not be NTT-friendly", pl.CoefficientModulus)
+		}
+	}
+	// Case: prime modulus with q = 1 mod 2n is NTT-friendly.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = big.NewInt(nttCoefficientModulus)
+	p, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	if !IsNTTFriendly(p) {
+		t.Errorf("coefficient modulus %d should be NTT-friendly", pl.CoefficientModulus)
+	}
+	// Case: prime modulus without a 2n-th root of unity (q = 12289 = 3 * 2^12 + 1, n = 2^13).
+	pl.CoefficientModulus = big.NewInt(12_289)
+	pl.Degree = 1 << 13
+	// The modulus is too small for the noise of valid parameters, which are not needed here.
+	p = &params.Params{Literal: pl}
+	if IsNTTFriendly(p) {
+		t.Errorf("coefficient modulus %d should not be NTT-friendly for size %d", pl.CoefficientModulus, p.Size())
+	}
+}
+
+// TestNTTPolyMult cross-checks the NTT multiplication with the exact integer product.
+func TestNTTPolyMult(t *testing.T) {
+	// Case: BFV and HERatio rings with an NTT-friendly modulus.
+	for _, pl := range []params.Literal{params.PLBFV32, params.PLHERatio16, params.PLHERatio512} {
+		pl.CoefficientModulus = big.NewInt(nttCoefficientModulus)
+		p, err := params.New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		// Random polynomials.
+		o := new(oracle.Oracle)
+		lb, ub := -int64(nttCoefficientModulus/2), int64(nttCoefficientModulus/2)
+		x, err := o.RandInt(lb, ub, p.Size())
+		if err != nil {
+			t.Error(err)
+		}
+		y, err := o.RandInt(lb, ub, p.Size())
+		if err != nil {
+			t.Error(err)
+		}
+		// Products.
+		prod, err := PolyMult(x, y, p)
+		if err != nil {
+			t.Error(err)
+		}
+		exact, err := IntPolyMult(x, y, p)
+		if err != nil {
+			t.Error(err)
+		}
+		ep := VecSymMod(exact, pl.CoefficientModulus)
+		for i := 0; i < len(ep); i++ {
+			if ep[i].Cmp(prod[i]) != 0 {
+				t.Errorf("expected %s at position [%d] for scheme %d but got %s", ep[i].String(), i, pl.Scheme, prod[i].String())
+				break
+			}
+		}
+	}
+	// Case: modulus that is not NTT-friendly.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	x := make([]*big.Int, p.Size())
+	for i := 0; i < len(x); i++ {
+		x[i] = big.NewInt(int64(i))
+	}
+	if _, err := NTTPolyMult(x, x, p); err != ErrModulusIsNotNTTFriendly {
+		t.Errorf("expected error %s", ErrModulusIsNotNTTFriendly)
+	}
+}
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrRangeIsNotValid = errors.New("lower bound is greater than or equal to upper bound")
 	ErrOutOfSamples    = errors.New("end of pseudo-random integer values")
+	ErrSeedIsNotValid  = errors.New("seed is not valid")
 )
@@ -18,22 +18,28 @@ type Oracle struct {
 
 // RandInt returns an array of n random integers inside the provided range.
 func (o *Oracle) RandInt(lb, ub int64, n int) ([]*big.Int, error) {
+	return o.RandBigInt(big.NewInt(lb), big.NewInt(ub), n)
+}
+
+// RandBigInt returns an array of n random integers inside the provided range,
+// whose bounds can be arbitrarily large.
+func (o *Oracle) RandBigInt(lb, ub *big.Int, n int) ([]*big.Int, error) {
 	// Check range.
-	if lb >= ub {
+	if lb.Cmp(ub) >= 0 {
 		return nil, ErrRangeIsNotValid
 	}
 	// Define the bias that will adjust random numbers to the intended range.
 	// Random numbers are picked between 0 and a positive integer. Therefore,
 	// we need to use a compensation (bias) to move the picked number into
 	// the valid range.
-	b := big.NewInt(lb)
+	b := new(big.Int).Set(lb)
 	// Range.
-	r := ub - lb
+	r := new(big.Int).Sub(ub, lb)
 	// Generate "degree" amount of random numbers in the interval [lowerBound, upperBound].
 	randomNumbers := []*big.Int{}
 	for i := 0; i < n; i++ {
 		// Generate random number.
-		rn, err := crand.Int(crand.Reader, big.NewInt(r))
+		rn, err := crand.Int(crand.Reader, r)
 		if err != nil {
 			return nil, err
 		}
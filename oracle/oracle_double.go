@@ -36,6 +36,12 @@ func (od *OracleDouble) RandInt(lb, ub int64, n int) ([]*big.Int, error) {
 	return rib, nil
 }
 
+// RandBigInt will return pseudo-random arrays until it runs out of samples. It shares
+// the samples with RandInt, so that both are read in the order they are called.
+func (od *OracleDouble) RandBigInt(lb, ub *big.Int, n int) ([]*big.Int, error) {
+	return od.RandInt(0, 0, n)
+}
+
 // NormDist will return pseudo-random normal distribution arrays until it runs out of samples.
 func (od *OracleDouble) NormDist(n int) []*big.Int {
 	// Check if there are still samples.
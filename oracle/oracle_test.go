@@ -12,6 +12,7 @@ func TestRandInt(t *testing.T) {
 	// Parameters.
 	pl := params.PLHERatio16
 	pl.Factor = 1
+	pl.Scheme = params.BFV
 	p, err := params.New(pl)
 	if err != nil {
 		t.Error(err)
@@ -102,3 +103,35 @@ func TestRandInt(t *testing.T) {
 		}
 	}
 }
+
+func TestRandBigInt(t *testing.T) {
+	// Oracle.
+	o := new(Oracle)
+	// Case: invalid range throws an error.
+	_, err := o.RandBigInt(big.NewInt(2), big.NewInt(-1), 16)
+	if err != ErrRangeIsNotValid {
+		t.Errorf("invalid range should throw error: %s", ErrRangeIsNotValid.Error())
+	}
+
+	// Case: random integers from a range wider than 64 bits.
+	ub := new(big.Int).Lsh(big.NewInt(1), 200)
+	lb := new(big.Int).Neg(ub)
+	ri, err := o.RandBigInt(lb, ub, 128)
+	if err != nil {
+		t.Error(err)
+	}
+	// Check if results are only in the range and exceed 64 bits.
+	wide := false
+	for i := 0; i < len(ri); i++ {
+		if ri[i].Cmp(lb) == -1 || ri[i].Cmp(ub) >= 0 {
+			t.Errorf("%d does not belong to the allowed range", ri[i])
+			break
+		}
+		if ri[i].BitLen() > 64 {
+			wide = true
+		}
+	}
+	if !wide {
+		t.Errorf("random integers should not be limited to 64 bits")
+	}
+}
@@ -7,4 +7,5 @@ import "math/big"
 type Randomizer interface {
 	NormDist(n int) []*big.Int
 	RandInt(lb, ub int64, n int) ([]*big.Int, error)
+	RandBigInt(lb, ub *big.Int, n int) ([]*big.Int, error)
 }
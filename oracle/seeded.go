@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"math/big"
+)
+
+// SeedSize is the size in bytes of the seeds of a Seeded source.
+const SeedSize = 32
+
+// Seeded is a deterministic random source that expands a seed with AES-256 in
+// counter mode, so that the values it returns can be generated again from the seed.
+type Seeded struct {
+	stream cipher.Stream
+}
+
+// NewSeeded creates a Seeded source from a seed of SeedSize bytes.
+func NewSeeded(seed []byte) (*Seeded, error) {
+	if len(seed) != SeedSize {
+		return nil, ErrSeedIsNotValid
+	}
+	b, err := aes.NewCipher(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &Seeded{stream: cipher.NewCTR(b, make([]byte, aes.BlockSize))}, nil
+}
+
+// RandInt returns an array of n integers inside the provided range.
+func (s *Seeded) RandInt(lb, ub int64, n int) ([]*big.Int, error) {
+	return s.RandBigInt(big.NewInt(lb), big.NewInt(ub), n)
+}
+
+// RandBigInt returns an array of n integers inside the provided range. Each value
+// is drawn from the bits of the stream by rejection, so it is uniform in the range.
+func (s *Seeded) RandBigInt(lb, ub *big.Int, n int) ([]*big.Int, error) {
+	// Check range.
+	if lb.Cmp(ub) >= 0 {
+		return nil, ErrRangeIsNotValid
+	}
+	// Range and the bytes needed to hold it.
+	r := new(big.Int).Sub(ub, lb)
+	k := r.BitLen()
+	buf := make([]byte, (k+7)/8)
+	// Mask for the unused bits of the most significant byte.
+	mask := byte(0xff >> (8*len(buf) - k))
+	randomNumbers := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		rn := new(big.Int)
+		for {
+			for j := range buf {
+				buf[j] = 0
+			}
+			s.stream.XORKeyStream(buf, buf)
+			buf[0] &= mask
+			if rn.SetBytes(buf).Cmp(r) < 0 {
+				break
+			}
+		}
+		// Move random number down in the range.
+		randomNumbers[i] = rn.Add(rn, lb)
+	}
+	return randomNumbers, nil
+}
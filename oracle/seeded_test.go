@@ -0,0 +1,78 @@
+package oracle
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestSeeded(t *testing.T) {
+	// Case: invalid seed throws an error.
+	if _, err := NewSeeded(make([]byte, 16)); err != ErrSeedIsNotValid {
+		t.Errorf("invalid seed should throw error: %s", ErrSeedIsNotValid.Error())
+	}
+	// Seeds.
+	seed := bytes.Repeat([]byte{7}, SeedSize)
+	other := bytes.Repeat([]byte{8}, SeedSize)
+	// Range wider than 64 bits.
+	ub := new(big.Int).Lsh(big.NewInt(1), 100)
+	lb := new(big.Int).Neg(ub)
+	sample := func(seed []byte) []*big.Int {
+		s, err := NewSeeded(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ri, err := s.RandBigInt(lb, ub, 256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ri
+	}
+	ri1, ri2, ri3 := sample(seed), sample(seed), sample(other)
+	// Case: the same seed expands to the same values, inside the range.
+	same, wide := true, false
+	for i := 0; i < len(ri1); i++ {
+		if ri1[i].Cmp(ri2[i]) != 0 {
+			t.Errorf("expected %d at position [%d] but got %d", ri1[i], i, ri2[i])
+			break
+		}
+		if ri1[i].Cmp(lb) == -1 || ri1[i].Cmp(ub) >= 0 {
+			t.Errorf("%d does not belong to the allowed range", ri1[i])
+			break
+		}
+		if ri1[i].BitLen() > 64 {
+			wide = true
+		}
+		same = same && ri1[i].Cmp(ri3[i]) == 0
+	}
+	if !wide {
+		t.Errorf("random integers should not be limited to 64 bits")
+	}
+	// Case: different seeds expand to different values.
+	if same {
+		t.Errorf("expected different values for different seeds")
+	}
+
+	// Case: all integers of a small range are sampled.
+	s, err := NewSeeded(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ri, err := s.RandInt(5, 15, 256)
+	if err != nil {
+		t.Error(err)
+	}
+	f := make(map[int64]bool)
+	for i := 0; i < len(ri); i++ {
+		f[ri[i].Int64()] = true
+	}
+	for i := int64(5); i < 15; i++ {
+		if !f[i] {
+			t.Errorf("%d did not appear in the desired range", i)
+			break
+		}
+	}
+	if len(f) != 10 {
+		t.Errorf("expected 10 distinct values but got %d", len(f))
+	}
+}
@@ -1,5 +1,7 @@
 package params
 
+import "math/big"
+
 const (
 	// Parameters for all schemes.
 	ExpansionBase                = 10
@@ -23,9 +25,9 @@ var (
 	PLHERatio16 = Literal{
 		Degree:                       1 << 4, // 16.
 		ExpansionBase:                ExpansionBase,
-		CoefficientModulus:           CoefficientModulus,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(CoefficientModulus),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       2,
@@ -35,9 +37,9 @@ var (
 	PLHERatio512 = Literal{
 		Degree:                       1 << 9, // 512.
 		ExpansionBase:                ExpansionBase,
-		CoefficientModulus:           CoefficientModulus,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(CoefficientModulus),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       2,
@@ -47,9 +49,9 @@ var (
 	PLBFV32 = Literal{
 		Degree:                       1 << 5, // 32.
 		ExpansionBase:                ExpansionBase,
-		CoefficientModulus:           CoefficientModulus,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(CoefficientModulus),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       1,
@@ -59,9 +61,9 @@ var (
 	PLBFV512 = Literal{
 		Degree:                       1 << 9, // 512.
 		ExpansionBase:                ExpansionBase,
-		CoefficientModulus:           CoefficientModulus,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(CoefficientModulus),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       1,
@@ -71,9 +73,9 @@ var (
 	PLBFV1024 = Literal{
 		Degree:                       1 << 10, // 1024.
 		ExpansionBase:                ExpansionBase,
-		CoefficientModulus:           CoefficientModulus,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(CoefficientModulus),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       1,
@@ -83,9 +85,9 @@ var (
 	PLBFV2048 = Literal{
 		Degree:                       1 << 11, // 2048.
 		ExpansionBase:                2,
-		CoefficientModulus:           18_014_398_509_481_983,
-		DecryptionModulus:            DecryptionModulus,
-		RelinearizationExpansionBase: RelinearizationExpansionBase,
+		CoefficientModulus:           big.NewInt(18_014_398_509_481_983),
+		DecryptionModulus:            big.NewInt(DecryptionModulus),
+		RelinearizationExpansionBase: big.NewInt(RelinearizationExpansionBase),
 		StandardDeviation:            Sigma,
 		Bound:                        Bound,
 		Factor:                       1,
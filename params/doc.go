@@ -4,6 +4,8 @@ instantiated as a set of parameters. The limits and relationships between
 the given values are be checked when a Parameters structure is instantiated.
 Therefore, a ParametersLiteral structure needs to be given as a set of
 constants with values that are securely sound before creating a Parameters object.
+The security level of a set of parameters is estimated by Params.SecurityLevel,
+and New rejects parameters below 128 bits of security when given the Strict option.
 */
 
 package params
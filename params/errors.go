@@ -1,14 +1,86 @@
 package params
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrDegreeIsNotAPowerOfTwo                          = errors.New("degree should be a power of 2")
-	ErrExpansionBaseIsNotEqualOrGreaterThanTwo         = errors.New("expansion base should be equal or greater than 2")
-	ErrRelinearizationExpansionBaseIsNotGreaterThanTwo = errors.New("expansion base for relinearization should be greater than 2")
-	ErrDegreeIsNotAPositiveInteger                     = errors.New("degree should be a positive integer")
-	ErrCoefficientModulusIsNil                         = errors.New("coefficient modulus cannot be nil")
-	ErrStandardDeviationIsNil                          = errors.New("standard deviation cannot be nil")
-	ErrSizeIsNotValid                                  = errors.New("degree should be a divisor for size")
-	ErrSchemeIsNotValid                                = errors.New("a valid scheme must be chosen")
+	ErrDegreeIsNotAPowerOfTwo                                      = errors.New("degree should be a power of 2")
+	ErrExpansionBaseIsNotEqualOrGreaterThanTwo                     = errors.New("expansion base should be equal or greater than 2")
+	ErrRelinearizationExpansionBaseIsNotGreaterThanTwo             = errors.New("expansion base for relinearization should be greater than 2")
+	ErrDegreeIsNotAPositiveInteger                                 = errors.New("degree should be a positive integer")
+	ErrCoefficientModulusIsNil                                     = errors.New("coefficient modulus cannot be nil")
+	ErrStandardDeviationIsNil                                      = errors.New("standard deviation cannot be nil")
+	ErrSizeIsNotValid                                              = errors.New("factor should be a positive integer")
+	ErrSchemeIsNotValid                                            = errors.New("a valid scheme must be chosen")
+	ErrDecryptionModulusIsNotValid                                 = errors.New("decryption modulus should be an integer equal or greater than 2")
+	ErrBoundIsNotAPositiveInteger                                  = errors.New("bound should be a positive integer")
+	ErrFactorIsNotValidForScheme                                   = errors.New("factor should be 2 for HERatio")
+	ErrDecryptionModulusIsNotLessThanCoefficientModulus            = errors.New("decryption modulus should be less than the coefficient modulus")
+	ErrDeltaIsNotGreaterThanNoiseBound                             = errors.New("delta = q/t should be greater than twice the noise bound of fresh ciphertexts")
+	ErrRelinearizationExpansionBaseIsNotLessThanCoefficientModulus = errors.New("expansion base for relinearization should be less than the coefficient modulus")
+	ErrExpansionBaseIsNotLessThanDecryptionModulus                 = errors.New("expansion base should be less than the decryption modulus")
+	ErrDegreeIsTooSmallForCodec                                    = errors.New("degree should be at least 4 for the SIM2D codec")
+	ErrDepthIsNotValid                                             = errors.New("multiplicative depth should be a non-negative integer")
+	ErrDigitsAreNotValid                                           = errors.New("number of digits should be non-negative and not all zero")
+	ErrSecurityLevelIsNotValid                                     = errors.New("security level should be 0, 128, 192 or 256 bits")
+	ErrSecurityLevelIsTooLow                                       = errors.New("estimated security level is below 128 bits")
+	ErrNoParametersFound                                           = errors.New("no parameters support the request")
+	ErrModulusIsNotADecimalInteger                                 = errors.New("moduli should be decimal integers")
 )
+
+// FieldError is a violation of the constraints of a field of Literal.
+type FieldError struct {
+	Field string // Name of the offending field.
+	Err   error  // Violated constraint.
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the violated constraint.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError holds every violation found when validating parameters.
+type ValidationError struct {
+	Errors []*FieldError // Violations, in the order of the checks.
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		s[i] = fe.Error()
+	}
+	return "invalid parameters: " + strings.Join(s, "; ")
+}
+
+// Is reports whether target is one of the violated constraints, so that
+// errors.Is(err, ErrDegreeIsNotAPowerOfTwo) finds it among the others.
+func (e *ValidationError) Is(target error) bool {
+	for _, fe := range e.Errors {
+		if fe.Err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns the names of the offending fields.
+func (e *ValidationError) Fields() []string {
+	var f []string
+	for _, fe := range e.Errors {
+		f = append(f, fe.Field)
+	}
+	return f
+}
+
+// add records a violation of the field, if err is not nil.
+func (e *ValidationError) add(field string, err error) {
+	if err != nil {
+		e.Errors = append(e.Errors, &FieldError{field, err})
+	}
+}
@@ -0,0 +1,83 @@
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// literalJSON is the JSON form of Literal, with the moduli as decimal strings.
+type literalJSON struct {
+	Degree                       int     `json:"degree"`
+	ExpansionBase                int64   `json:"expansionBase"`
+	CoefficientModulus           string  `json:"coefficientModulus,omitempty"`
+	DecryptionModulus            string  `json:"decryptionModulus,omitempty"`
+	RelinearizationExpansionBase string  `json:"relinearizationExpansionBase,omitempty"`
+	StandardDeviation            float64 `json:"standardDeviation"`
+	Bound                        int     `json:"bound"`
+	Factor                       int     `json:"factor"`
+	Scheme                       int     `json:"scheme"`
+}
+
+// MarshalJSON encodes the literal with its moduli as decimal strings.
+func (l Literal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(literalJSON{
+		Degree:                       l.Degree,
+		ExpansionBase:                l.ExpansionBase,
+		CoefficientModulus:           decimal(l.CoefficientModulus),
+		DecryptionModulus:            decimal(l.DecryptionModulus),
+		RelinearizationExpansionBase: decimal(l.RelinearizationExpansionBase),
+		StandardDeviation:            l.StandardDeviation,
+		Bound:                        l.Bound,
+		Factor:                       l.Factor,
+		Scheme:                       l.Scheme,
+	})
+}
+
+// UnmarshalJSON decodes a literal encoded by MarshalJSON. The literal is not
+// validated, which is done by New.
+func (l *Literal) UnmarshalJSON(data []byte) error {
+	var lj literalJSON
+	if err := json.Unmarshal(data, &lj); err != nil {
+		return err
+	}
+	q, ok := parseDecimal(lj.CoefficientModulus)
+	if !ok {
+		return ErrModulusIsNotADecimalInteger
+	}
+	t, ok := parseDecimal(lj.DecryptionModulus)
+	if !ok {
+		return ErrModulusIsNotADecimalInteger
+	}
+	w, ok := parseDecimal(lj.RelinearizationExpansionBase)
+	if !ok {
+		return ErrModulusIsNotADecimalInteger
+	}
+	*l = Literal{
+		Degree:                       lj.Degree,
+		ExpansionBase:                lj.ExpansionBase,
+		CoefficientModulus:           q,
+		DecryptionModulus:            t,
+		RelinearizationExpansionBase: w,
+		StandardDeviation:            lj.StandardDeviation,
+		Bound:                        lj.Bound,
+		Factor:                       lj.Factor,
+		Scheme:                       lj.Scheme,
+	}
+	return nil
+}
+
+// decimal returns the decimal string of x, or an empty string for nil.
+func decimal(x *big.Int) string {
+	if x == nil {
+		return ""
+	}
+	return x.String()
+}
+
+// parseDecimal parses a decimal string, where an empty string stands for nil.
+func parseDecimal(s string) (*big.Int, bool) {
+	if s == "" {
+		return nil, true
+	}
+	return new(big.Int).SetString(s, 10)
+}
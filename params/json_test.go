@@ -0,0 +1,45 @@
+package params
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLiteralJSON(t *testing.T) {
+	for _, l := range []Literal{PLHERatio16, PLBFV32, PLBFV2048} {
+		data, err := json.Marshal(l)
+		if err != nil {
+			t.Error(err)
+		}
+		// Case: the moduli are decimal strings.
+		if s := `"coefficientModulus":"` + l.CoefficientModulus.String() + `"`; !strings.Contains(string(data), s) {
+			t.Errorf("expected %s in %s", s, data)
+		}
+		// Case: the decoded literal gives the same parameters.
+		var dl Literal
+		if err := json.Unmarshal(data, &dl); err != nil {
+			t.Error(err)
+		}
+		p, err := New(l)
+		if err != nil {
+			t.Error(err)
+		}
+		dp, err := New(dl)
+		if err != nil {
+			t.Error(err)
+		}
+		if p.Fingerprint() != dp.Fingerprint() {
+			t.Errorf("expected the literal to be restored from %s", data)
+		}
+	}
+	// Case: moduli that are not decimal integers.
+	var l Literal
+	if err := json.Unmarshal([]byte(`{"degree":16,"coefficientModulus":"0x10"}`), &l); err != ErrModulusIsNotADecimalInteger {
+		t.Errorf("expected error %s but got %v", ErrModulusIsNotADecimalInteger, err)
+	}
+	// Case: missing moduli are nil.
+	if err := json.Unmarshal([]byte(`{"degree":16}`), &l); err != nil || l.CoefficientModulus != nil {
+		t.Errorf("expected a nil coefficient modulus but got %v (%v)", l.CoefficientModulus, err)
+	}
+}
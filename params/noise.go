@@ -0,0 +1,178 @@
+package params
+
+import (
+	"math"
+	"math/big"
+)
+
+// The noise of a ciphertext c is the polynomial v in c0 + c1*s = delta*m + v (mod q).
+// Ciphertexts carry two estimates of the infinity norm of v as their logarithm in
+// base 2, which are updated by every operation without the secret key:
+//   - the worst case, a bound that holds for any sample of the errors and keys;
+//   - the average case, a bound of 6 standard deviations on the coefficients,
+//     which assumes that the coefficients of the products of polynomials are sums
+//     of independent terms.
+// The size n = Factor * Degree is the size of the polynomials of the scheme, and
+// the decryption is correct while the noise is below NoiseCeiling.
+
+// avgBound is the number of standard deviations of the average-case estimate.
+const avgBound = 6
+
+// Noise is the worst-case and average-case estimate of the noise of a ciphertext.
+type Noise struct {
+	Worst float64 // Worst-case estimate (log2).
+	Avg   float64 // Average-case estimate (log2).
+}
+
+// NoNoise is the estimate of a ciphertext without noise.
+var NoNoise = Noise{math.Inf(-1), math.Inf(-1)}
+
+// RelinearizationDigits returns the number of digits of the coefficient modulus in
+// the relinearization expansion base, i.e., floor(log_w(q)) + 1.
+func (p *Params) RelinearizationDigits() int {
+	w := p.RelinearizationExpansionBase()
+	l := 0
+	for q := p.CoefficientModulus(); q.Sign() > 0; q.Quo(q, w) {
+		l++
+	}
+	return l
+}
+
+// NoiseCeiling returns the largest noise estimate for which the decryption is
+// still correct, i.e., Log2(delta/2) with delta = floor(q/t).
+func (p *Params) NoiseCeiling() float64 {
+	return Log2(new(big.Int).Quo(p.CoefficientModulus(), p.DecryptionModulus())) - 1
+}
+
+// FreshNoise returns the noise estimate of a fresh ciphertext. With the public
+// key (-a*s + e, a), the noise is v = e*u + e0 + e1*s. Its coefficients are
+// bounded by B*(2n + 1) with B = Bound * StandardDeviation, and have variance
+// sigma^2 * (4n/3 + 1), since u and s have ternary coefficients.
+func (p *Params) FreshNoise() Noise {
+	n := float64(p.Size())
+	sd := p.StandardDeviation()
+	b := float64(p.Bound()) * sd
+	return Noise{
+		Worst: math.Log2(b * (2*n + 1)),
+		Avg:   math.Log2(avgBound * sd * math.Sqrt(4*n/3+1)),
+	}
+}
+
+// FreshSymNoise returns the noise estimate of a ciphertext encrypted with the secret
+// key, (-a*s + e + delta*m, a), whose noise is e alone, bounded by B.
+func (p *Params) FreshSymNoise() Noise {
+	sd := p.StandardDeviation()
+	return Noise{
+		Worst: math.Log2(float64(p.Bound()) * sd),
+		Avg:   math.Log2(avgBound * sd),
+	}
+}
+
+// SumNoise returns the noise estimate of the sum of two ciphertexts. The bounds
+// add up in the worst case, and the variances add up in the average case.
+func SumNoise(a, b Noise) Noise {
+	return Noise{
+		Worst: addNoise(a.Worst, b.Worst),
+		Avg:   addNoise(2*a.Avg, 2*b.Avg) / 2,
+	}
+}
+
+// PlainNoise returns the noise estimate after the addition of a plaintext. The
+// rounding of delta = floor(q/t) adds at most t to the noise.
+func (p *Params) PlainNoise(a Noise) Noise {
+	t := float64(p.DecryptionModulus().BitLen())
+	return Noise{addNoise(a.Worst, t), addNoise(a.Avg, t)}
+}
+
+// ScalarNoise returns the noise estimate after the multiplication by the scalar s.
+func ScalarNoise(a Noise, s float64) Noise {
+	if s == 0 {
+		return NoNoise
+	}
+	l := math.Log2(math.Abs(s))
+	return Noise{a.Worst + l, a.Avg + l}
+}
+
+// PlainMultNoise returns the noise estimate after the multiplication by the plaintext
+// polynomial m, which grows the noise by ||m||_1 in the worst case and by ||m||_2
+// in the average case.
+func PlainMultNoise(a Noise, m []*big.Int) Noise {
+	l1, l2 := new(big.Int), new(big.Int)
+	for i := 0; i < len(m); i++ {
+		l1.Add(l1, new(big.Int).Abs(m[i]))
+		l2.Add(l2, new(big.Int).Mul(m[i], m[i]))
+	}
+	if l1.Sign() == 0 {
+		return NoNoise
+	}
+	return Noise{a.Worst + Log2(l1), a.Avg + Log2(l2)/2}
+}
+
+// TensorNoise returns the noise estimate of the tensor product of two ciphertexts,
+// which is dominated by t*(v0*k1 + v1*k0), where k = (c0 + c1*s - delta*m - v)/q.
+// It is about t*n*(v0 + v1) in the worst case. In the average case, since c1 is
+// uniform modulo q and s is ternary, the coefficients of k have standard
+// deviation sqrt(n/18), and those of v*k have standard deviation n*v/sqrt(18).
+func (p *Params) TensorNoise(a, b Noise) Noise {
+	n := math.Log2(float64(p.Size()))
+	t := float64(p.DecryptionModulus().BitLen())
+	return Noise{
+		Worst: t + n + addNoise(a.Worst, b.Worst),
+		Avg:   t + n - math.Log2(18)/2 + addNoise(a.Avg, b.Avg),
+	}
+}
+
+// RelinNoise returns the noise estimate after the relinearization, which adds the
+// products of the l digits of the expansion in base w, in [-w/2, w/2), by the
+// errors of the evaluation key. It is l*n*B*w/2 in the worst case, and the
+// variance of each coefficient is l*n*sigma^2*w^2/12.
+func (p *Params) RelinNoise(a Noise) Noise {
+	ln := float64(p.RelinearizationDigits() * p.Size())
+	w := p.RelinearizationExpansionBase()
+	sd := p.StandardDeviation()
+	bd := float64(p.Bound()) * sd
+	return Noise{
+		Worst: addNoise(a.Worst, math.Log2(ln*bd/2)+float64(w.BitLen())),
+		Avg:   addNoise(a.Avg, math.Log2(avgBound*sd*math.Sqrt(ln/12))+Log2(w)),
+	}
+}
+
+// MultNoise returns the noise estimate after the multiplication of two ciphertexts
+// and the relinearization of the result.
+func (p *Params) MultNoise(a, b Noise) Noise {
+	return p.RelinNoise(p.TensorNoise(a, b))
+}
+
+// PowNoise returns the noise estimate of the k-th power of a ciphertext computed
+// as x^k = x^ceil(k/2) * x^floor(k/2), the same order as Evaluator.Pow in the
+// scheme package.
+func (p *Params) PowNoise(a Noise, k int) Noise {
+	if k == 1 {
+		return a
+	}
+	if k%2 == 0 {
+		b := p.PowNoise(a, k/2)
+		return p.MultNoise(b, b)
+	}
+	return p.MultNoise(p.PowNoise(a, k/2+1), p.PowNoise(a, k/2))
+}
+
+// addNoise returns Log2(2^a + 2^b), the bound of the sum of two bounded terms.
+func addNoise(a, b float64) float64 {
+	m, d := math.Max(a, b), math.Min(a, b)
+	if math.IsInf(m, -1) {
+		return m
+	}
+	return m + math.Log2(1+math.Exp2(d-m))
+}
+
+// Log2 returns the logarithm in base 2 of a positive integer of any size.
+func Log2(x *big.Int) float64 {
+	// Only the 64 most significant bits matter for the precision of a float64.
+	s := x.BitLen() - 64
+	if s < 0 {
+		s = 0
+	}
+	f, _ := new(big.Float).SetInt(new(big.Int).Rsh(x, uint(s))).Float64()
+	return float64(s) + math.Log2(f)
+}
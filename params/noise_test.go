@@ -0,0 +1,90 @@
+package params
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRelinearizationDigits(t *testing.T) {
+	for _, pl := range []Literal{PLHERatio16, PLBFV32, PLBFV2048} {
+		p, err := New(pl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Case: w^(l-1) <= q < w^l.
+		l := p.RelinearizationDigits()
+		w := p.RelinearizationExpansionBase()
+		lo := new(big.Int).Exp(w, big.NewInt(int64(l-1)), nil)
+		hi := new(big.Int).Mul(lo, w)
+		if q := p.CoefficientModulus(); q.Cmp(lo) < 0 || q.Cmp(hi) >= 0 {
+			t.Errorf("expected %d digits in base %s for q = %s", l, w, q)
+		}
+	}
+	// Case: q around exact powers of the relinearization expansion base (w = 128),
+	// where floating-point logarithms are not reliable, and q with hundreds of bits.
+	w5 := new(big.Int).Exp(big.NewInt(RelinearizationExpansionBase), big.NewInt(5), nil)
+	cases := []struct {
+		q *big.Int
+		l int
+	}{
+		{big.NewInt(CoefficientModulus), 5},
+		{new(big.Int).Sub(w5, big.NewInt(1)), 5},
+		{w5, 6},
+		{new(big.Int).Lsh(big.NewInt(1), 256), 37},
+	}
+	for _, c := range cases {
+		pl := PLBFV32
+		pl.CoefficientModulus = c.q
+		p, err := New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		if l := p.RelinearizationDigits(); l != c.l {
+			t.Errorf("expected %d digits for modulus %s but got %d", c.l, c.q.String(), l)
+		}
+	}
+}
+
+func TestNoise(t *testing.T) {
+	p, err := New(PLBFV32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := p.FreshNoise()
+	// Case: the average case is below the worst case.
+	if fresh.Avg >= fresh.Worst {
+		t.Errorf("expected average-case estimate below %f but got %f", fresh.Worst, fresh.Avg)
+	}
+	// Case: the noise of the secret-key encryption is below the one of the public key.
+	if s := p.FreshSymNoise(); s.Worst >= fresh.Worst || s.Avg >= fresh.Avg {
+		t.Errorf("expected estimates below %+v but got %+v", fresh, s)
+	}
+	// Case: the sum of two equal estimates doubles the bound and the variance.
+	if s := SumNoise(fresh, fresh); s.Worst != fresh.Worst+1 || math.Abs(s.Avg-fresh.Avg-0.5) > 1e-9 {
+		t.Errorf("expected estimate {%f %f} but got %+v", fresh.Worst+1, fresh.Avg+0.5, s)
+	}
+	// Case: the multiplication by zero leaves no noise.
+	if s := ScalarNoise(fresh, 0); s != NoNoise {
+		t.Errorf("expected no noise but got %+v", s)
+	}
+	if s := PlainMultNoise(fresh, []*big.Int{big.NewInt(0)}); s != NoNoise {
+		t.Errorf("expected no noise but got %+v", s)
+	}
+	// Case: the multiplication is the tensor product followed by the relinearization.
+	if m, r := p.MultNoise(fresh, fresh), p.RelinNoise(p.TensorNoise(fresh, fresh)); m != r {
+		t.Errorf("expected estimate %+v but got %+v", r, m)
+	}
+	// Case: the square is the power 2, and the powers grow.
+	if s, p2 := p.MultNoise(fresh, fresh), p.PowNoise(fresh, 2); s != p2 {
+		t.Errorf("expected estimate %+v but got %+v", s, p2)
+	}
+	if p3 := p.PowNoise(fresh, 3); p3.Worst <= p.PowNoise(fresh, 2).Worst {
+		t.Errorf("expected the estimate of the power 3 above the one of the power 2 but got %+v", p3)
+	}
+	// Case: the ceiling is log2(delta/2).
+	d := new(big.Int).Quo(p.CoefficientModulus(), p.DecryptionModulus())
+	if c := p.NoiseCeiling(); math.Abs(c-math.Log2(float64(d.Int64())/2)) > 1e-9 {
+		t.Errorf("expected ceiling %f but got %f", math.Log2(float64(d.Int64())/2), c)
+	}
+}
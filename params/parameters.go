@@ -1,35 +1,54 @@
 package params
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
 	"math"
+	"math/big"
 )
 
 // Literal is the structure that holds the information
 // that will be used throughout calculations.
 type Literal struct {
-	Degree                       int     // Degree.
-	ExpansionBase                int64   // Expansion base (modulo).
-	CoefficientModulus           int64   // Coefficient modulus.
-	DecryptionModulus            int64   // Decryption modulus.
-	RelinearizationExpansionBase int64   // Relinearize expansion base.
-	StandardDeviation            float64 // Standard deviation of discrete Gaussian (Sigma).
-	Bound                        int     // Boundary for the standard deviation.
-	Factor                       int     // Multiplication factor that gives the size of ciphertexts.
-	Scheme                       int     // Chosen scheme.
+	Degree                       int      // Degree.
+	ExpansionBase                int64    // Expansion base (modulo).
+	CoefficientModulus           *big.Int // Coefficient modulus.
+	DecryptionModulus            *big.Int // Decryption modulus.
+	RelinearizationExpansionBase *big.Int // Relinearize expansion base.
+	StandardDeviation            float64  // Standard deviation of discrete Gaussian (Sigma).
+	Bound                        int      // Boundary for the standard deviation.
+	Factor                       int      // Multiplication factor that gives the size of ciphertexts.
+	Scheme                       int      // Chosen scheme.
 }
 
 // Params struct organizes the information that will be used
 // throughout calculations.
 type Params struct {
-	Literal Literal // Set of parameters.
+	Literal     Literal // Set of parameters.
+	minSecurity int     // Minimum security level (bits) required by the validation.
+}
+
+// Option changes the validation of the parameters made by New.
+type Option func(*Params)
+
+// Strict makes New reject parameters with an estimated security level
+// below 128 bits (see Params.SecurityLevel).
+func Strict() Option {
+	return func(p *Params) {
+		p.minSecurity = Security128
+	}
 }
 
 // New creates a struct that validates all parameters
 // used for encoding and decoding.
-func New(l Literal) (*Params, error) {
+func New(l Literal, opts ...Option) (*Params, error) {
 	// New parameters from literal.
 	p := new(Params)
 	p.Literal = l
+	for _, opt := range opts {
+		opt(p)
+	}
 	// Validate parameters.
 	err := p.validate()
 
@@ -46,19 +65,19 @@ func (p *Params) ExpansionBase() int64 {
 	return p.Literal.ExpansionBase
 }
 
-// Getter for the coefficient modulus.
-func (p *Params) CoefficientModulus() int64 {
-	return p.Literal.CoefficientModulus
+// Getter for the coefficient modulus. A copy is returned so that callers cannot change the parameters.
+func (p *Params) CoefficientModulus() *big.Int {
+	return clone(p.Literal.CoefficientModulus)
 }
 
-// Getter for the decryption modulus.
-func (p *Params) DecryptionModulus() int64 {
-	return p.Literal.DecryptionModulus
+// Getter for the decryption modulus. A copy is returned so that callers cannot change the parameters.
+func (p *Params) DecryptionModulus() *big.Int {
+	return clone(p.Literal.DecryptionModulus)
 }
 
-// Getter for the relinearize expansion base.
-func (p *Params) RelinearizationExpansionBase() int64 {
-	return p.Literal.RelinearizationExpansionBase
+// Getter for the relinearize expansion base. A copy is returned so that callers cannot change the parameters.
+func (p *Params) RelinearizationExpansionBase() *big.Int {
+	return clone(p.Literal.RelinearizationExpansionBase)
 }
 
 // Getter for the standard deviation.
@@ -86,38 +105,47 @@ func (p *Params) Size() int {
 	return p.Factor() * p.Degree()
 }
 
+// Fingerprint identifies the parameter set. It is given by the first 8 bytes of
+// the SHA-256 digest of the literal parameters, so that keys, plaintexts and
+// ciphertexts created with different parameters can be told apart. It is computed
+// on every call, since the literal parameters are exported and can be changed.
+func (p *Params) Fingerprint() uint64 {
+	l := p.Literal
+	s := fmt.Sprintf("%d|%d|%s|%s|%s|%g|%d|%d|%d", l.Degree, l.ExpansionBase, l.CoefficientModulus, l.DecryptionModulus,
+		l.RelinearizationExpansionBase, l.StandardDeviation, l.Bound, l.Factor, l.Scheme)
+	h := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// validate checks every field of the parameters and the relationships between
+// them, and returns a *ValidationError with all the violations found.
 func (p *Params) validate() error {
-	// Validate degree.
-	if err := p.validateDegree(); err != nil {
-		return err
-	}
-	// Validate expansion base.
-	if err := p.validateExpansionBase(); err != nil {
-		return err
-	}
-	// Validate coefficient modulus.
-	if err := p.validateCoefficientModulus(); err != nil {
-		return err
-	}
-	// Validate relinearization expansion base.
-	if err := p.validateRelinearizationExpansionBase(); err != nil {
-		return err
-	}
-	// Validate standard deviation.
-	if err := p.validateStandardDeviation(); err != nil {
-		return err
-	}
-	// Validate Bound.
-	if err := p.validateBound(); err != nil {
-		return err
+	v := new(ValidationError)
+	// Validate each field.
+	v.add("Degree", p.validateDegree())
+	v.add("ExpansionBase", p.validateExpansionBase())
+	v.add("CoefficientModulus", p.validateCoefficientModulus())
+	v.add("DecryptionModulus", p.validateDecryptionModulus())
+	v.add("RelinearizationExpansionBase", p.validateRelinearizationExpansionBase())
+	v.add("StandardDeviation", p.validateStandardDeviation())
+	v.add("Bound", p.validateBound())
+	v.add("Factor", p.validateSize())
+	v.add("Scheme", p.validateScheme())
+	// The relationships are only checked between valid fields.
+	if len(v.Errors) == 0 {
+		v.add("Factor", p.validateFactor())
+		v.add("DecryptionModulus", p.validateModuli())
+		v.add("CoefficientModulus", p.validateDelta())
+		v.add("RelinearizationExpansionBase", p.validateRelinearizationExpansionBaseRange())
+		v.add("ExpansionBase", p.validateDigits())
+		v.add("Degree", p.validateCodecDegree())
 	}
-	// Validate Size.
-	if err := p.validateSize(); err != nil {
-		return err
+	// Validate security level.
+	if len(v.Errors) == 0 {
+		v.add("CoefficientModulus", p.validateSecurityLevel())
 	}
-	// Validate Scheme.
-	if err := p.validateScheme(); err != nil {
-		return err
+	if len(v.Errors) > 0 {
+		return v
 	}
 	return nil
 }
@@ -137,7 +165,7 @@ func (p *Params) validateDegree() error {
 }
 
 func (p *Params) validateExpansionBase() error {
-	// Expansion base must be greater than 2.
+	// Expansion base must be at least 2.
 	if p.ExpansionBase() < 2 {
 		return ErrExpansionBaseIsNotEqualOrGreaterThanTwo
 	}
@@ -146,15 +174,25 @@ func (p *Params) validateExpansionBase() error {
 
 func (p *Params) validateCoefficientModulus() error {
 	// Coefficient modulus cannot be nil.
-	if p.CoefficientModulus() <= 0 {
+	if p.Literal.CoefficientModulus == nil || p.Literal.CoefficientModulus.Sign() <= 0 {
 		return ErrCoefficientModulusIsNil
 	}
 	return nil
 }
 
+func (p *Params) validateDecryptionModulus() error {
+	// Decryption modulus must be at least 2.
+	t := p.Literal.DecryptionModulus
+	if t == nil || t.Cmp(big.NewInt(2)) < 0 {
+		return ErrDecryptionModulusIsNotValid
+	}
+	return nil
+}
+
 func (p *Params) validateRelinearizationExpansionBase() error {
 	// Expansion base for relinearization must be greater than 2.
-	if p.RelinearizationExpansionBase() <= 2 {
+	w := p.Literal.RelinearizationExpansionBase
+	if w == nil || w.Cmp(big.NewInt(2)) <= 0 {
 		return ErrRelinearizationExpansionBaseIsNotGreaterThanTwo
 	}
 	return nil
@@ -171,13 +209,13 @@ func (p *Params) validateStandardDeviation() error {
 func (p *Params) validateBound() error {
 	// Bound must be a positive integer.
 	if p.Bound() <= 0 {
-		return ErrDegreeIsNotAPositiveInteger
+		return ErrBoundIsNotAPositiveInteger
 	}
 	return nil
 }
 
 func (p *Params) validateSize() error {
-	// Size must be positive.
+	// Factor must be positive, so that the size is a multiple of the degree.
 	if p.Factor() <= 0 {
 		return ErrSizeIsNotValid
 	}
@@ -192,3 +230,71 @@ func (p *Params) validateScheme() error {
 	}
 	return nil
 }
+
+func (p *Params) validateFactor() error {
+	// The Laurent codec of HERatio needs Degree fractional and Degree integer positions.
+	if p.Scheme() == HERatio && p.Factor() != 2 {
+		return ErrFactorIsNotValidForScheme
+	}
+	return nil
+}
+
+func (p *Params) validateModuli() error {
+	// t must be smaller than q.
+	if p.Literal.DecryptionModulus.Cmp(p.Literal.CoefficientModulus) >= 0 {
+		return ErrDecryptionModulusIsNotLessThanCoefficientModulus
+	}
+	return nil
+}
+
+func (p *Params) validateDelta() error {
+	// The noise of a fresh ciphertext, bounded by B*(2*Size + 1) with
+	// B = Bound * StandardDeviation, must be below delta/2.
+	b := float64(p.Bound()) * p.StandardDeviation() * float64(2*p.Size()+1)
+	d := new(big.Int).Quo(p.Literal.CoefficientModulus, p.Literal.DecryptionModulus)
+	if f, _ := new(big.Float).SetInt(d).Float64(); f <= 2*b {
+		return ErrDeltaIsNotGreaterThanNoiseBound
+	}
+	return nil
+}
+
+func (p *Params) validateRelinearizationExpansionBaseRange() error {
+	// The expansion of q in base w must have more than one digit.
+	if p.Literal.RelinearizationExpansionBase.Cmp(p.Literal.CoefficientModulus) >= 0 {
+		return ErrRelinearizationExpansionBaseIsNotLessThanCoefficientModulus
+	}
+	return nil
+}
+
+func (p *Params) validateDigits() error {
+	// The digits of the codecs, in (-b/2, b/2], must be represented modulo t.
+	if p.Literal.DecryptionModulus.Cmp(big.NewInt(p.ExpansionBase())) <= 0 {
+		return ErrExpansionBaseIsNotLessThanDecryptionModulus
+	}
+	return nil
+}
+
+func (p *Params) validateCodecDegree() error {
+	// The SIM2D codec of BFV needs at least one integer and one fractional position
+	// besides the units, i.e., Degree/2 - 1 > 0.
+	if p.Scheme() == BFV && p.Degree() < 4 {
+		return ErrDegreeIsTooSmallForCodec
+	}
+	return nil
+}
+
+func (p *Params) validateSecurityLevel() error {
+	// The security level is only checked in strict mode.
+	if p.minSecurity > 0 && p.SecurityLevel() < p.minSecurity {
+		return ErrSecurityLevelIsTooLow
+	}
+	return nil
+}
+
+// clone returns a copy of x, or nil if x is nil.
+func clone(x *big.Int) *big.Int {
+	if x == nil {
+		return nil
+	}
+	return new(big.Int).Set(x)
+}
@@ -1,6 +1,9 @@
 package params
 
 import (
+	"errors"
+	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -32,7 +35,7 @@ func TestValidateDegree(t *testing.T) {
 	if err == nil {
 		t.Errorf("an invalid degree should throw an error")
 	} else {
-		if err != ErrDegreeIsNotAPositiveInteger {
+		if !errors.Is(err, ErrDegreeIsNotAPositiveInteger) {
 			t.Errorf("the invalid degree should throw the error: %s", ErrDegreeIsNotAPositiveInteger)
 		}
 	}
@@ -45,7 +48,7 @@ func TestValidateDegree(t *testing.T) {
 	if err == nil {
 		t.Errorf("an invalid degree should throw an error")
 	} else {
-		if err != ErrDegreeIsNotAPowerOfTwo {
+		if !errors.Is(err, ErrDegreeIsNotAPowerOfTwo) {
 			t.Errorf("the invalid degree should throw the error: %s", ErrDegreeIsNotAPowerOfTwo)
 		}
 	}
@@ -74,7 +77,7 @@ func TestValidateExpansionBase(t *testing.T) {
 	if err == nil {
 		t.Errorf("an invalid expansion base should throw an error")
 	} else {
-		if err != ErrExpansionBaseIsNotEqualOrGreaterThanTwo {
+		if !errors.Is(err, ErrExpansionBaseIsNotEqualOrGreaterThanTwo) {
 			t.Errorf("the invalid expansion base should throw the error: %s", ErrExpansionBaseIsNotEqualOrGreaterThanTwo)
 		}
 	}
@@ -84,19 +87,42 @@ func TestValidateCoefficientModulus(t *testing.T) {
 	// Case: null coefficient modulus.
 	// Parameter literals.
 	pl := PLHERatio16
-	pl.CoefficientModulus = 0
+	pl.CoefficientModulus = nil
 	// New parameters.
 	_, err := New(pl)
 	if err == nil {
 		t.Errorf("a nil coefficient modulus should throw an error")
 	}
+
+	// Case: zero coefficient modulus.
+	pl.CoefficientModulus = big.NewInt(0)
+	_, err = New(pl)
+	if err == nil {
+		t.Errorf("a zero coefficient modulus should throw an error")
+	}
+
+	// Case: coefficient modulus with hundreds of bits.
+	q := new(big.Int).Lsh(big.NewInt(1), 300)
+	pl.CoefficientModulus = new(big.Int).Add(q, big.NewInt(1))
+	p, err := New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	if p.CoefficientModulus().Cmp(pl.CoefficientModulus) != 0 {
+		t.Errorf("expected coefficient modulus %s but got %s", pl.CoefficientModulus, p.CoefficientModulus())
+	}
+	// The getter must return a copy.
+	p.CoefficientModulus().SetInt64(0)
+	if p.CoefficientModulus().Cmp(pl.CoefficientModulus) != 0 {
+		t.Errorf("the coefficient modulus should not be changed through its getter")
+	}
 }
 
 func TestValidateRelinearizationExpansionBase(t *testing.T) {
 	// Case: null relinearization expansion base.
 	// Parameter literals.
 	pl := PLHERatio16
-	pl.RelinearizationExpansionBase = 0
+	pl.RelinearizationExpansionBase = big.NewInt(0)
 	// New parameters.
 	_, err := New(pl)
 	if err == nil {
@@ -104,18 +130,18 @@ func TestValidateRelinearizationExpansionBase(t *testing.T) {
 	}
 
 	// Case: valid relinearization expansion base.
-	pl.RelinearizationExpansionBase = 10
+	pl.RelinearizationExpansionBase = big.NewInt(10)
 	_, err = New(pl)
 	if err != nil {
 		t.Errorf("a valid relinearization expansion base should not throw an error")
 	}
 	// Case: invalid relinearization expansion base (not greater than 2).
-	pl.RelinearizationExpansionBase = 2
+	pl.RelinearizationExpansionBase = big.NewInt(2)
 	_, err = New(pl)
 	if err == nil {
 		t.Errorf("an invalid relinearization expansion base should throw an error")
 	} else {
-		if err != ErrRelinearizationExpansionBaseIsNotGreaterThanTwo {
+		if !errors.Is(err, ErrRelinearizationExpansionBaseIsNotGreaterThanTwo) {
 			t.Errorf("the invalid relinearization expansion base should throw the error: %s", ErrRelinearizationExpansionBaseIsNotGreaterThanTwo)
 		}
 	}
@@ -140,8 +166,8 @@ func TestValidateBounds(t *testing.T) {
 	pl.Bound = 0
 	// New parameters.
 	_, err := New(pl)
-	if err == nil {
-		t.Errorf("a nil bound should throw an error")
+	if !errors.Is(err, ErrBoundIsNotAPositiveInteger) {
+		t.Errorf("a nil bound should throw the error: %s", ErrBoundIsNotAPositiveInteger)
 	}
 }
 
@@ -152,15 +178,19 @@ func TestValidateFactor(t *testing.T) {
 	// Size is zero.
 	pl.Factor = 0
 	_, err := New(pl)
-	if err != ErrSizeIsNotValid {
+	if !errors.Is(err, ErrSizeIsNotValid) {
 		t.Errorf("size cannot be less than or equal to zero")
 	}
 	// Factor is negative.
 	pl.Factor = -2
 	_, err = New(pl)
-	if err != ErrSizeIsNotValid {
+	if !errors.Is(err, ErrSizeIsNotValid) {
 		t.Errorf("size cannot be less than or equal to zero")
 	}
+	// Case: the violation names the factor.
+	if !strings.Contains(err.Error(), "Factor: factor should be a positive integer") {
+		t.Errorf("expected the factor to be reported but got %q", err)
+	}
 }
 
 func TestValidateScheme(t *testing.T) {
@@ -169,7 +199,119 @@ func TestValidateScheme(t *testing.T) {
 	pl := PLHERatio16
 	pl.Scheme = -1
 	_, err := New(pl)
-	if err != ErrSchemeIsNotValid {
+	if !errors.Is(err, ErrSchemeIsNotValid) {
 		t.Errorf("scheme should not be valid")
 	}
 }
+
+func TestValidateRelationships(t *testing.T) {
+	tests := []struct {
+		field  string
+		err    error
+		modify func(*Literal)
+	}{
+		// Case: decryption modulus is missing.
+		{"DecryptionModulus", ErrDecryptionModulusIsNotValid, func(l *Literal) { l.DecryptionModulus = nil }},
+		// Case: HERatio with factor 1.
+		{"Factor", ErrFactorIsNotValidForScheme, func(l *Literal) { l.Factor = 1 }},
+		// Case: t >= q.
+		{"DecryptionModulus", ErrDecryptionModulusIsNotLessThanCoefficientModulus, func(l *Literal) { l.DecryptionModulus = l.CoefficientModulus }},
+		// Case: q/t does not leave room for the noise of fresh ciphertexts.
+		{"CoefficientModulus", ErrDeltaIsNotGreaterThanNoiseBound, func(l *Literal) { l.CoefficientModulus = big.NewInt(1 << 20) }},
+		// Case: w >= q.
+		{"RelinearizationExpansionBase", ErrRelinearizationExpansionBaseIsNotLessThanCoefficientModulus, func(l *Literal) {
+			l.RelinearizationExpansionBase = l.CoefficientModulus
+		}},
+		// Case: the digits of the codec do not fit modulo t.
+		{"ExpansionBase", ErrExpansionBaseIsNotLessThanDecryptionModulus, func(l *Literal) { l.ExpansionBase = 2131 }},
+		// Case: BFV degree without fractional positions.
+		{"Degree", ErrDegreeIsTooSmallForCodec, func(l *Literal) { l.Scheme, l.Factor, l.Degree = BFV, 1, 2 }},
+	}
+	for _, tt := range tests {
+		pl := PLHERatio16
+		tt.modify(&pl)
+		_, err := New(pl)
+		// The first violation is the one of the case, which may imply others (e.g., t >= q
+		// makes delta too small).
+		var v *ValidationError
+		if !errors.As(err, &v) || len(v.Errors) == 0 {
+			t.Errorf("expected a violation of %s but got %v", tt.field, err)
+			continue
+		}
+		if fe := v.Errors[0]; fe.Field != tt.field || !errors.Is(fe, tt.err) {
+			t.Errorf("expected %s: %s but got %s", tt.field, tt.err, fe)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	// Case: every violation is reported with its field.
+	pl := PLHERatio16
+	pl.Degree = 3
+	pl.Bound = 0
+	pl.DecryptionModulus = big.NewInt(1)
+	_, err := New(pl)
+	var v *ValidationError
+	if !errors.As(err, &v) {
+		t.Fatalf("expected a validation error but got %v", err)
+	}
+	ef := []string{"Degree", "DecryptionModulus", "Bound"}
+	f := v.Fields()
+	if len(f) != len(ef) {
+		t.Fatalf("expected fields %v but got %v", ef, f)
+	}
+	for i := 0; i < len(ef); i++ {
+		if f[i] != ef[i] {
+			t.Errorf("expected field %s at position [%d] but got %s", ef[i], i, f[i])
+		}
+	}
+	for _, e := range []error{ErrDegreeIsNotAPowerOfTwo, ErrDecryptionModulusIsNotValid, ErrBoundIsNotAPositiveInteger} {
+		if !errors.Is(err, e) {
+			t.Errorf("expected error %s in %s", e, err)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	// Case: the same literal parameters give the same fingerprint.
+	p0, err := New(PLHERatio16)
+	if err != nil {
+		t.Error(err)
+	}
+	p1, err := New(PLHERatio16)
+	if err != nil {
+		t.Error(err)
+	}
+	if p0.Fingerprint() != p1.Fingerprint() {
+		t.Errorf("expected fingerprint %x but got %x", p0.Fingerprint(), p1.Fingerprint())
+	}
+	// Case: the fingerprint is that of the literal parameters.
+	if f := (&Params{Literal: PLHERatio16}).Fingerprint(); f != p0.Fingerprint() {
+		t.Errorf("expected fingerprint %x but got %x", p0.Fingerprint(), f)
+	}
+	// Case: a change of the literal parameters changes the fingerprint.
+	p1.Literal.CoefficientModulus = new(big.Int).Add(p1.Literal.CoefficientModulus, big.NewInt(2))
+	if p1.Fingerprint() == p0.Fingerprint() {
+		t.Errorf("expected a new fingerprint after a change of the coefficient modulus")
+	}
+
+	// Case: different parameters give different fingerprints.
+	for _, pl := range []Literal{PLHERatio512, PLBFV32, PLBFV2048} {
+		p, err := New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		if p.Fingerprint() == p0.Fingerprint() {
+			t.Errorf("parameters with degree %d and scheme %d should not share the fingerprint %x", pl.Degree, pl.Scheme, p0.Fingerprint())
+		}
+	}
+	pl := PLHERatio16
+	pl.CoefficientModulus = new(big.Int).Add(pl.CoefficientModulus, big.NewInt(2))
+	p, err := New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	if p.Fingerprint() == p0.Fingerprint() {
+		t.Errorf("parameters with coefficient modulus %s should not share the fingerprint %x", pl.CoefficientModulus, p0.Fingerprint())
+	}
+}
@@ -0,0 +1,101 @@
+package params
+
+import "math"
+
+// Security levels (bits) of the HomomorphicEncryption.org standard.
+const (
+	Security128 = 128
+	Security192 = 192
+	Security256 = 256
+)
+
+// maxLogQ holds the largest bit length of the coefficient modulus for each
+// ring dimension and security level, as given by the tables of the
+// HomomorphicEncryption.org standard for ternary secrets and a standard
+// deviation of 3.19 (classical attacks).
+var maxLogQ = map[int]map[int]int{
+	1 << 10: {Security128: 27, Security192: 19, Security256: 14},
+	1 << 11: {Security128: 54, Security192: 37, Security256: 29},
+	1 << 12: {Security128: 109, Security192: 75, Security256: 58},
+	1 << 13: {Security128: 218, Security192: 152, Security256: 118},
+	1 << 14: {Security128: 438, Security192: 305, Security256: 237},
+	1 << 15: {Security128: 881, Security192: 611, Security256: 476},
+}
+
+// MaxCoefficientModulusBits returns the largest bit length of the coefficient
+// modulus that reaches the security level for polynomials of the given size,
+// and false when the standard has no entry for them.
+func MaxCoefficientModulusBits(size, security int) (int, bool) {
+	l, ok := maxLogQ[size][security]
+	return l, ok
+}
+
+// SecurityLevel returns an estimate of the bits of security of the parameters
+// against lattice attacks on the ring-LWE problem of dimension Size. For the sizes
+// of the HomomorphicEncryption.org standard, it is the highest level (128, 192 or
+// 256) whose bound holds for the coefficient modulus. Otherwise, and for moduli
+// beyond the 128-bit bound, it is the cost of the cheaper of the primal and dual
+// attacks given by attackCost.
+func (p *Params) SecurityLevel() int {
+	lq := p.Literal.CoefficientModulus.BitLen()
+	if _, ok := MaxCoefficientModulusBits(p.Size(), Security128); ok {
+		for _, s := range []int{Security256, Security192, Security128} {
+			if l, _ := MaxCoefficientModulusBits(p.Size(), s); lq <= l {
+				return s
+			}
+		}
+		// The estimate must not contradict the standard.
+		return int(math.Min(attackCost(p.Size(), lq, p.StandardDeviation()), Security128-1))
+	}
+	return int(attackCost(p.Size(), lq, p.StandardDeviation()))
+}
+
+// attackCost returns the log2 of the cost of the cheaper of the primal (uSVP) and
+// dual (distinguishing) attacks on LWE with dimension n, a modulus of lq bits and
+// errors of standard deviation sigma. It is an approximation that follows the
+// lattice estimator with BKZ sieving (0.292*beta + 16.4), and is within about 15
+// bits of the tables of the standard.
+func attackCost(n, lq int, sigma float64) float64 {
+	return math.Min(primalCost(float64(n), float64(lq), sigma), dualCost(float64(n), float64(lq), sigma))
+}
+
+// primalCost returns the cost of the primal attack, which embeds m samples into a
+// lattice of dimension d = m + n + 1 and succeeds with BKZ-beta when
+// sigma*sqrt(beta) <= delta^(2*beta - d) * q^(m/d).
+func primalCost(n, lq, sigma float64) float64 {
+	step := math.Max(1, math.Floor(n/64))
+	for b := 40.0; b <= 3*n+1; b++ {
+		ld := math.Log2(rootHermiteFactor(b))
+		for m := step; m <= 2*n; m += step {
+			d := m + n + 1
+			if b <= d && math.Log2(sigma*math.Sqrt(b)) <= (2*b-d)*ld+m/d*lq {
+				return bkzCost(b)
+			}
+		}
+	}
+	return bkzCost(3*n + 1)
+}
+
+// dualCost returns the cost of the dual attack, which finds a short vector of
+// length l = delta^d * q^(n/d) in the dual lattice, with advantage
+// eps = exp(-pi*(l*sigma*sqrt(2*pi)/q)^2), and repeats it 1/eps^2 times.
+func dualCost(n, lq, sigma float64) float64 {
+	c := math.Inf(1)
+	for b := 40.0; b <= 3*n+1; b++ {
+		ld := math.Log2(rootHermiteFactor(b))
+		d := math.Min(math.Sqrt(n*lq/ld), 2*n)
+		x := math.Exp2(d*ld+n/d*lq-lq) * sigma * math.Sqrt(2*math.Pi)
+		c = math.Min(c, bkzCost(b)+math.Max(0, 2*math.Pi*x*x/math.Ln2))
+	}
+	return c
+}
+
+// rootHermiteFactor returns the root Hermite factor delta reached by BKZ with block size b.
+func rootHermiteFactor(b float64) float64 {
+	return math.Pow(b/(2*math.Pi*math.E)*math.Pow(math.Pi*b, 1/b), 1/(2*(b-1)))
+}
+
+// bkzCost returns the log2 of the cost of BKZ with block size b and sieving.
+func bkzCost(b float64) float64 {
+	return 0.292*b + 16.4
+}
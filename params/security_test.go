@@ -0,0 +1,73 @@
+package params
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestSecurityLevel(t *testing.T) {
+	tests := []struct {
+		l   Literal
+		min int
+		max int
+	}{
+		// Case: toy parameters are insecure.
+		{PLHERatio16, 0, 64},
+		{PLBFV32, 0, 64},
+		{PLBFV1024, 0, 127},
+		// Case: secure parameters (54 bits for size 2048).
+		{PLBFV2048, Security128, Security128},
+	}
+	for _, tt := range tests {
+		p, err := New(tt.l)
+		if err != nil {
+			t.Error(err)
+		}
+		if s := p.SecurityLevel(); s < tt.min || s > tt.max {
+			t.Errorf("expected security level in [%d, %d] for size %d but got %d", tt.min, tt.max, p.Size(), s)
+		}
+	}
+	// Case: the levels of the standard at the bounds of the table, with a small t
+	// that leaves room for the noise.
+	l := PLBFV2048
+	l.DecryptionModulus = big.NewInt(3)
+	for _, s := range []int{Security128, Security192, Security256} {
+		b, _ := MaxCoefficientModulusBits(l.Degree, s)
+		l.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), uint(b-1))
+		p, err := New(l)
+		if err != nil {
+			t.Error(err)
+		}
+		if ps := p.SecurityLevel(); ps != s {
+			t.Errorf("expected security level %d for %d bits but got %d", s, b, ps)
+		}
+	}
+}
+
+func TestAttackCost(t *testing.T) {
+	// Case: the cost model is close to the 128-bit bounds of the standard.
+	for size, b := range maxLogQ {
+		if c := attackCost(size, b[Security128], Sigma); c < 108 || c > 150 {
+			t.Errorf("expected about 128 bits for size %d and %d bits but got %f", size, b[Security128], c)
+		}
+	}
+	// Case: the cost decreases with the modulus.
+	if attackCost(1<<12, 80, Sigma) <= attackCost(1<<12, 100, Sigma) {
+		t.Errorf("expected the cost to decrease with the modulus")
+	}
+}
+
+func TestStrict(t *testing.T) {
+	// Case: insecure parameters are rejected in strict mode only.
+	if _, err := New(PLHERatio16); err != nil {
+		t.Error(err)
+	}
+	if _, err := New(PLHERatio16, Strict()); !errors.Is(err, ErrSecurityLevelIsTooLow) {
+		t.Errorf("expected error %s but got %v", ErrSecurityLevelIsTooLow, err)
+	}
+	// Case: secure parameters are accepted in strict mode.
+	if _, err := New(PLBFV2048, Strict()); err != nil {
+		t.Error(err)
+	}
+}
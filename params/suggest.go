@@ -0,0 +1,190 @@
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// maxDegree is the largest degree considered by Suggest.
+const maxDegree = 1 << 15
+
+// Request describes the workload for which Suggest chooses parameters.
+type Request struct {
+	Scheme           int   // BFV (SIM2D codec) or HERatio (Laurent codec).
+	Depth            int   // Multiplicative depth, i.e., the number of successive squarings of a message.
+	IntegerDigits    int   // Number of integer digits of the messages.
+	FractionalDigits int   // Number of fractional digits of the messages.
+	Security         int   // Security level in bits (128, 192 or 256), or 0 for no security requirement.
+	ExpansionBase    int64 // Expansion base of the codec, ExpansionBase (10) if zero.
+}
+
+// Suggestion holds the parameters chosen by Suggest with the reasons behind them.
+type Suggestion struct {
+	Literal       Literal  // Validated parameters.
+	Justification []string // Why the parameters support the request.
+}
+
+// String returns the justification, one reason per line.
+func (s *Suggestion) String() string {
+	return strings.Join(s.Justification, "\n")
+}
+
+// Suggest chooses the parameters of the smallest degree that support the request:
+//   - the degree gives the codec enough positions for the digits of the messages
+//     raised to the power 2^Depth, so that the encoding does not wrap around;
+//   - the decryption modulus t bounds the coefficients of the encoding, which grow
+//     with every multiplication since the digits are not carried;
+//   - the coefficient modulus q is an NTT-friendly prime large enough for the
+//     worst-case noise estimate of the noise model (see Noise) after Depth
+//     multiplications and relinearizations;
+//   - the degree is doubled until q is within the HomomorphicEncryption.org
+//     bound of the security level.
+//
+// Messages are assumed to be exact with the given digits (e.g., 1.25 has one integer
+// and two fractional digits in base 10), and are otherwise rounded by the codecs.
+func Suggest(req Request) (*Suggestion, error) {
+	b := req.ExpansionBase
+	if b == 0 {
+		b = ExpansionBase
+	}
+	if err := req.validate(b); err != nil {
+		return nil, err
+	}
+	factor, codec := 1, "SIM2D"
+	if req.Scheme == HERatio {
+		factor, codec = 2, "Laurent"
+	}
+	// Degree for the digits of the result. With symmetric digits, a message takes the
+	// integer positions 0, ..., I (one more for the carry) and the fractional positions
+	// -1, ..., -F, which become 0, ..., I*2^d and -1, ..., -F*2^d after d squarings.
+	n := 4
+	for ; n <= maxDegree; n *= 2 {
+		ip, fp := positions(n, req.Scheme)
+		if ip>>req.Depth > req.IntegerDigits && fp>>req.Depth >= req.FractionalDigits {
+			break
+		}
+	}
+	if n > maxDegree {
+		return nil, ErrNoParametersFound
+	}
+	// Decryption modulus. The k nonzero digits of an encoding, bounded by B, give
+	// at most 2k-1 digits bounded by k*B^2 after a squaring.
+	k := big.NewInt(int64(req.IntegerDigits + req.FractionalDigits + 1))
+	bd := big.NewInt(b / 2)
+	for i := 0; i < req.Depth; i++ {
+		bd.Mul(bd.Mul(bd, bd), k)
+		k.Sub(k.Lsh(k, 1), big.NewInt(1))
+	}
+	t := nextPrime(new(big.Int).Lsh(bd, 1), 2)
+	// Coefficient modulus, doubling the degree until it reaches the security level.
+	w := big.NewInt(RelinearizationExpansionBase)
+	for ; n <= maxDegree; n *= 2 {
+		size := factor * n
+		l := Literal{
+			Degree:                       n,
+			ExpansionBase:                b,
+			DecryptionModulus:            t,
+			RelinearizationExpansionBase: w,
+			StandardDeviation:            Sigma,
+			Bound:                        Bound,
+			Factor:                       factor,
+			Scheme:                       req.Scheme,
+		}
+		v, q := suggestModulus(l, req.Depth)
+		limit, ok := MaxCoefficientModulusBits(size, req.Security)
+		if req.Security != 0 && (!ok || q.BitLen() > limit) {
+			continue
+		}
+		l.CoefficientModulus = q
+		var opts []Option
+		if req.Security != 0 {
+			opts = append(opts, Strict())
+		}
+		p, err := New(l, opts...)
+		if err != nil {
+			return nil, err
+		}
+		s := &Suggestion{Literal: l}
+		ip, fp := positions(n, req.Scheme)
+		s.Justification = append(s.Justification,
+			fmt.Sprintf("Degree %d: the %s codec has %d integer and %d fractional positions, and the messages need %d and %d after %d multiplications.",
+				n, codec, ip, fp, req.IntegerDigits<<req.Depth+1, req.FractionalDigits<<req.Depth, req.Depth),
+			fmt.Sprintf("DecryptionModulus %s: the coefficients of the encoding are bounded by %s after %d multiplications, below t/2.",
+				t, bd, req.Depth),
+			fmt.Sprintf("CoefficientModulus (%d bits, prime and 1 mod %d for the NTT): the worst-case noise after %d multiplications is 2^%.1f, below q/(2t) = 2^%.1f.",
+				q.BitLen(), 2*size, req.Depth, v, p.NoiseCeiling()))
+		if req.Security == 0 {
+			s.Justification = append(s.Justification, "Security: not requested.")
+		} else {
+			s.Justification = append(s.Justification,
+				fmt.Sprintf("Security: %d bits, since the standard allows up to %d bits of coefficient modulus for size %d.",
+					req.Security, limit, size))
+		}
+		return s, nil
+	}
+	return nil, ErrNoParametersFound
+}
+
+// validate checks that a request can be supported.
+func (req Request) validate(b int64) error {
+	if req.Scheme != BFV && req.Scheme != HERatio {
+		return ErrSchemeIsNotValid
+	}
+	if b < 2 {
+		return ErrExpansionBaseIsNotEqualOrGreaterThanTwo
+	}
+	if req.Depth < 0 {
+		return ErrDepthIsNotValid
+	}
+	if req.IntegerDigits < 0 || req.FractionalDigits < 0 || req.IntegerDigits+req.FractionalDigits == 0 {
+		return ErrDigitsAreNotValid
+	}
+	switch req.Security {
+	case 0, Security128, Security192, Security256:
+		return nil
+	}
+	return ErrSecurityLevelIsNotValid
+}
+
+// positions returns the number of integer and fractional positions of the codec
+// of a scheme with degree n.
+func positions(n, scheme int) (int, int) {
+	if scheme == HERatio {
+		// Laurent: exponents -n, ..., n-1.
+		return n, n
+	}
+	// SIM2D: exponents -n/2, ..., n/2-1.
+	return n / 2, n / 2
+}
+
+// suggestModulus returns the worst-case noise estimate (log2) after depth squarings
+// of a fresh ciphertext, each followed by a relinearization, and the smallest
+// NTT-friendly prime q for which it is below the noise ceiling. The literal l gives
+// the other parameters.
+func suggestModulus(l Literal, depth int) (float64, *big.Int) {
+	bits := l.DecryptionModulus.BitLen() + 2
+	for {
+		l.CoefficientModulus = nextPrime(new(big.Int).Lsh(big.NewInt(1), uint(bits-1)), 2*l.Factor*l.Degree)
+		p := &Params{Literal: l}
+		v := p.FreshNoise()
+		for i := 0; i < depth; i++ {
+			v = p.MultNoise(v, v)
+		}
+		if v.Worst < p.NoiseCeiling() {
+			return v.Worst, l.CoefficientModulus
+		}
+		bits++
+	}
+}
+
+// nextPrime returns the smallest prime p > x with p = 1 (mod m).
+func nextPrime(x *big.Int, m int) *big.Int {
+	bm := big.NewInt(int64(m))
+	p := new(big.Int).Sub(x, new(big.Int).Mod(x, bm))
+	p.Add(p, big.NewInt(1))
+	for p.Cmp(x) <= 0 || !p.ProbablyPrime(20) {
+		p.Add(p, bm)
+	}
+	return p
+}
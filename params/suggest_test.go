@@ -0,0 +1,84 @@
+package params
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []Request{
+		// Case: BFV without security requirement.
+		{Scheme: BFV, Depth: 2, IntegerDigits: 1, FractionalDigits: 2},
+		// Case: HERatio without security requirement and base 2.
+		{Scheme: HERatio, Depth: 3, IntegerDigits: 4, FractionalDigits: 4, ExpansionBase: 2},
+		// Case: BFV at 128 bits of security.
+		{Scheme: BFV, Depth: 3, IntegerDigits: 2, FractionalDigits: 2, Security: Security128},
+		// Case: HERatio at 256 bits of security.
+		{Scheme: HERatio, Depth: 1, IntegerDigits: 3, FractionalDigits: 3, Security: Security256},
+	}
+	for _, req := range tests {
+		s, err := Suggest(req)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		l := s.Literal
+		// The literal must be valid.
+		p, err := New(l)
+		if err != nil {
+			t.Error(err)
+		}
+		// The codec must have positions for the digits of the result.
+		ip, fp := positions(l.Degree, req.Scheme)
+		if ip <= req.IntegerDigits<<req.Depth || fp < req.FractionalDigits<<req.Depth {
+			t.Errorf("expected positions for %d integer and %d fractional digits but got %d and %d", req.IntegerDigits<<req.Depth+1, req.FractionalDigits<<req.Depth, ip, fp)
+		}
+		// Both moduli must be prime, and q must be NTT-friendly.
+		if !l.CoefficientModulus.ProbablyPrime(20) || !l.DecryptionModulus.ProbablyPrime(20) {
+			t.Errorf("expected prime moduli but got %s and %s", l.CoefficientModulus, l.DecryptionModulus)
+		}
+		if r := new(big.Int).Mod(l.CoefficientModulus, big.NewInt(int64(2*p.Size()))); r.Int64() != 1 {
+			t.Errorf("expected q = 1 mod %d but got %d", 2*p.Size(), r)
+		}
+		// The coefficient modulus must be within the bound of the security level.
+		if req.Security != 0 {
+			limit, ok := MaxCoefficientModulusBits(p.Size(), req.Security)
+			if !ok || l.CoefficientModulus.BitLen() > limit {
+				t.Errorf("expected at most %d bits for size %d but got %d", limit, p.Size(), l.CoefficientModulus.BitLen())
+			}
+		}
+		// The worst-case noise after Depth squarings must be below the ceiling.
+		if v := p.PowNoise(p.FreshNoise(), 1<<req.Depth); v.Worst >= p.NoiseCeiling() {
+			t.Errorf("expected noise estimate below %f but got %f", p.NoiseCeiling(), v.Worst)
+		}
+		if len(s.Justification) != 4 {
+			t.Errorf("expected 4 reasons but got %d", len(s.Justification))
+		}
+		t.Logf("%+v\n%s", req, s)
+	}
+}
+
+func TestSuggestErrors(t *testing.T) {
+	tests := []struct {
+		req Request
+		err error
+	}{
+		// Case: invalid scheme.
+		{Request{Scheme: 2, IntegerDigits: 1}, ErrSchemeIsNotValid},
+		// Case: invalid expansion base.
+		{Request{IntegerDigits: 1, ExpansionBase: 1}, ErrExpansionBaseIsNotEqualOrGreaterThanTwo},
+		// Case: negative depth.
+		{Request{Depth: -1, IntegerDigits: 1}, ErrDepthIsNotValid},
+		// Case: no digits.
+		{Request{Depth: 1}, ErrDigitsAreNotValid},
+		// Case: invalid security level.
+		{Request{IntegerDigits: 1, Security: 100}, ErrSecurityLevelIsNotValid},
+		// Case: too many digits for the largest degree.
+		{Request{Depth: 20, IntegerDigits: 1}, ErrNoParametersFound},
+	}
+	for _, tt := range tests {
+		if _, err := Suggest(tt.req); err != tt.err {
+			t.Errorf("expected error %v for %+v but got %v", tt.err, tt.req, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package scheme
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// Ring is the context of the polynomial arithmetic of the chosen scheme,
+// i.e., the size of the polynomials, the coefficient modulus and the product.
+type Ring struct {
+	params  *params.Params // Parameters.
+	n       int            // Size of the polynomials.
+	q       *big.Int       // Coefficient modulus.
+	half    *big.Int       // ceil(q/2), threshold for the symmetric modulo.
+	ntt     *nttTable      // Twiddle factors, or nil if q is not NTT-friendly.
+	scratch sync.Pool      // Temporary integers of the products (*mulScratch).
+}
+
+// mulScratch holds the temporary integers of a product, which are reused by the
+// following products of the ring.
+type mulScratch struct {
+	a, b []*big.Int // Transforms of the operands (NTT).
+	c    []*big.Int // Linear convolution of the operands.
+	ws   []*big.Int // Workspace of the convolution.
+	v    *big.Int   // Temporary integer.
+	quo  *big.Int   // Temporary quotient of the reductions (NTT).
+}
+
+// NewRing creates the ring context from the parameters.
+func NewRing(p *params.Params) *Ring {
+	q := p.CoefficientModulus()
+	half := new(big.Int).Add(q, big.NewInt(1))
+	half.Rsh(half, 1)
+	r := &Ring{params: p, n: p.Size(), q: q, half: half, ntt: nttTableFor(p)}
+	r.scratch.New = func() interface{} {
+		s := &mulScratch{v: new(big.Int), quo: new(big.Int)}
+		if r.ntt != nil {
+			s.a, s.b = newInts(r.n), newInts(r.n)
+		} else {
+			s.c, s.ws = newInts(2*r.n-1), newInts(karatsubaWorkspace(r.n))
+		}
+		return s
+	}
+	return r
+}
+
+// Params returns the parameters of the ring.
+func (r *Ring) Params() *params.Params {
+	return r.params
+}
+
+// NewPoly creates a zero polynomial in the ring.
+func (r *Ring) NewPoly() *Poly {
+	c := make([]*big.Int, r.n)
+	for i := 0; i < len(c); i++ {
+		c[i] = new(big.Int)
+	}
+	return &Poly{Coeffs: c, ring: r}
+}
+
+// Poly wraps the given coefficients into a polynomial of the ring without copying
+// them, so that in-place operations on the polynomial change the coefficients.
+func (r *Ring) Poly(c []*big.Int) *Poly {
+	return &Poly{Coeffs: c, ring: r}
+}
+
+// Poly is a polynomial whose arithmetic is defined by its ring. Its methods
+// follow the math/big convention: the receiver holds the result, which can be
+// one of the operands, and is returned to allow chaining.
+type Poly struct {
+	Coeffs []*big.Int // Coefficients.
+	ring   *Ring      // Ring context.
+}
+
+// CopyFrom sets p to a copy of a.
+func (p *Poly) CopyFrom(a *Poly) *Poly {
+	for i := 0; i < len(p.Coeffs); i++ {
+		p.Coeffs[i].Set(a.Coeffs[i])
+	}
+	return p
+}
+
+// Add sets p to a + b.
+func (p *Poly) Add(a, b *Poly) *Poly {
+	for i := 0; i < len(p.Coeffs); i++ {
+		p.Coeffs[i].Add(a.Coeffs[i], b.Coeffs[i])
+	}
+	return p
+}
+
+// Sub sets p to a - b.
+func (p *Poly) Sub(a, b *Poly) *Poly {
+	for i := 0; i < len(p.Coeffs); i++ {
+		p.Coeffs[i].Sub(a.Coeffs[i], b.Coeffs[i])
+	}
+	return p
+}
+
+// Neg sets p to -a.
+func (p *Poly) Neg(a *Poly) *Poly {
+	for i := 0; i < len(p.Coeffs); i++ {
+		p.Coeffs[i].Neg(a.Coeffs[i])
+	}
+	return p
+}
+
+// MulScalar sets p to s * a.
+func (p *Poly) MulScalar(a *Poly, s *big.Int) *Poly {
+	for i := 0; i < len(p.Coeffs); i++ {
+		p.Coeffs[i].Mul(a.Coeffs[i], s)
+	}
+	return p
+}
+
+// Mul sets p to the product a * b in the ring of the chosen scheme, with the same
+// result as PolyMult. The temporary integers are taken from the ring and reused,
+// so that the product does not allocate new coefficients.
+func (p *Poly) Mul(a, b *Poly) (*Poly, error) {
+	r := p.ring
+	sc := r.scratch.Get().(*mulScratch)
+	defer r.scratch.Put(sc)
+	n, d := r.n, r.params.Degree()
+	scheme := r.params.Scheme()
+	if scheme != params.BFV && scheme != params.HERatio {
+		return nil, ErrSchemeIsNotValid
+	}
+	if t := r.ntt; t != nil {
+		// Negacyclic convolution, reduced modulo q (see NTTPolyMult).
+		t.forward(sc.a, a.Coeffs, sc.v, sc.quo)
+		t.forward(sc.b, b.Coeffs, sc.v, sc.quo)
+		for i := 0; i < n; i++ {
+			t.mod(sc.a[i].Mul(sc.a[i], sc.b[i]), sc.quo)
+		}
+		t.inverse(sc.a, sc.v, sc.quo)
+		if scheme == params.BFV {
+			for i := 0; i < n; i++ {
+				p.Coeffs[i].Set(sc.a[i])
+			}
+			return p.SymModInPlace(), nil
+		}
+		// Laurent polynomials: multiplication by x^(-d) = -x^d.
+		for i := 0; i < d; i++ {
+			p.Coeffs[i].Set(sc.a[i+d])
+			p.Coeffs[i+d].Set(sc.a[i])
+		}
+		p.SymModInPlace()
+		for i := d; i < n; i++ {
+			p.Coeffs[i].Neg(p.Coeffs[i])
+		}
+		return p, nil
+	}
+	// Linear convolution over the integers (see IntPolyMult).
+	c := sc.c
+	if n >= KaratsubaThreshold {
+		karatsubaInto(c, a.Coeffs, b.Coeffs, sc.ws)
+	} else {
+		schoolbookInto(c, a.Coeffs, b.Coeffs, sc.v)
+	}
+	if scheme == params.BFV {
+		// x^n = -1.
+		for i := 0; i < n-1; i++ {
+			p.Coeffs[i].Sub(c[i], c[n+i])
+		}
+		p.Coeffs[n-1].Set(c[n-1])
+		return p, nil
+	}
+	// Laurent polynomials of size 2d, stored with an offset of d in their powers.
+	for i := 0; i < n; i++ {
+		p.Coeffs[i].Set(c[d+i])
+		if i < d-1 {
+			p.Coeffs[i].Sub(p.Coeffs[i], c[3*d+i])
+		}
+		if i >= d {
+			p.Coeffs[i].Sub(p.Coeffs[i], c[i-d])
+		}
+	}
+	return p, nil
+}
+
+// SymModInPlace reduces the coefficients of p into the symmetric range of the
+// coefficient modulus, with the same convention as utils.SymMod.
+func (p *Poly) SymModInPlace() *Poly {
+	r := p.ring
+	// The quotient is kept in a single variable to avoid allocations.
+	quo := new(big.Int)
+	for i := 0; i < len(p.Coeffs); i++ {
+		c := p.Coeffs[i]
+		quo.QuoRem(c, r.q, c)
+		if c.Sign() < 0 {
+			c.Add(c, r.q)
+		}
+		if c.Cmp(r.half) >= 0 {
+			c.Sub(c, r.q)
+		}
+	}
+	return p
+}
+
+// divRound sets the coefficients of p to round(num * p / den), with the same
+// rounding as DivRound.
+func (p *Poly) divRound(num, den *big.Int) *Poly {
+	one := big.NewInt(1)
+	rem, h := new(big.Int), new(big.Int).Quo(den, big.NewInt(2))
+	for i := 0; i < len(p.Coeffs); i++ {
+		c := p.Coeffs[i]
+		c.Mul(c, num)
+		c.QuoRem(c, den, rem)
+		if rem.Sign() != 0 && rem.CmpAbs(h) >= 0 {
+			if rem.Sign() > 0 {
+				c.Add(c, one)
+			} else {
+				c.Sub(c, one)
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,160 @@
+package scheme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// randomPoly samples a polynomial with coefficients in [-q, q).
+func randomPoly(t *testing.T, r *Ring) *Poly {
+	q := r.Params().CoefficientModulus()
+	c, err := new(oracle.Oracle).RandBigInt(new(big.Int).Neg(q), q, r.n)
+	if err != nil {
+		t.Error(err)
+	}
+	return r.Poly(c)
+}
+
+func TestPolyArithmetic(t *testing.T) {
+	// Case: integer and NTT products.
+	nb, nh := params.PLBFV32, params.PLHERatio16
+	nb.CoefficientModulus = big.NewInt(nttCoefficientModulus)
+	nh.CoefficientModulus = big.NewInt(nttCoefficientModulus)
+	for _, pl := range []params.Literal{params.PLBFV32, params.PLHERatio16, nb, nh} {
+		// Parameters.
+		p, err := params.New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		r := NewRing(p)
+		q := p.CoefficientModulus()
+		a, b := randomPoly(t, r), randomPoly(t, r)
+		s := big.NewInt(-params.MS)
+		// Case: in-place operations match the free functions.
+		add := r.NewPoly().Add(a, b)
+		sub := r.NewPoly().Sub(a, b)
+		neg := r.NewPoly().Neg(a)
+		ms := r.NewPoly().MulScalar(a, s)
+		mul, err := r.NewPoly().Mul(a, b)
+		if err != nil {
+			t.Error(err)
+		}
+		em, err := PolyMult(a.Coeffs, b.Coeffs, p)
+		if err != nil {
+			t.Error(err)
+		}
+		sum := SumZip(a.Coeffs, b.Coeffs, p)
+		for i := 0; i < r.n; i++ {
+			if add.Coeffs[i].Cmp(sum[i]) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", sum[i].String(), i, add.Coeffs[i].String())
+				break
+			}
+			if d := new(big.Int).Sub(a.Coeffs[i], b.Coeffs[i]); sub.Coeffs[i].Cmp(d) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", d.String(), i, sub.Coeffs[i].String())
+				break
+			}
+			if d := new(big.Int).Neg(a.Coeffs[i]); neg.Coeffs[i].Cmp(d) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", d.String(), i, neg.Coeffs[i].String())
+				break
+			}
+			if d := new(big.Int).Mul(a.Coeffs[i], s); ms.Coeffs[i].Cmp(d) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", d.String(), i, ms.Coeffs[i].String())
+				break
+			}
+			if mul.Coeffs[i].Cmp(em[i]) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", em[i].String(), i, mul.Coeffs[i].String())
+				break
+			}
+		}
+		// Case: symmetric modulo matches VecSymMod.
+		esm := VecSymMod(mul.Coeffs, q)
+		mul.SymModInPlace()
+		for i := 0; i < r.n; i++ {
+			if mul.Coeffs[i].Cmp(esm[i]) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", esm[i].String(), i, mul.Coeffs[i].String())
+				break
+			}
+		}
+		// Case: the receiver can be one of the operands.
+		c := r.NewPoly().CopyFrom(a)
+		if _, err := c.Mul(c, b); err != nil {
+			t.Error(err)
+		}
+		c.Add(c, c).Sub(c, b)
+		for i := 0; i < r.n; i++ {
+			e := new(big.Int).Lsh(em[i], 1)
+			e.Sub(e, b.Coeffs[i])
+			if c.Coeffs[i].Cmp(e) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", e.String(), i, c.Coeffs[i].String())
+				break
+			}
+		}
+	}
+}
+
+func TestSymModInPlace(t *testing.T) {
+	// Case: even and odd moduli, including the boundaries of the symmetric range.
+	for _, m := range []int64{10, 11} {
+		pl := params.PLBFV32
+		pl.CoefficientModulus = big.NewInt(m)
+		// The ring only needs q, which is too small for valid parameters.
+		p := &params.Params{Literal: pl}
+		r := NewRing(p)
+		a := r.NewPoly()
+		for i := 0; i < r.n; i++ {
+			a.Coeffs[i].SetInt64(int64(i) - int64(r.n)/2)
+		}
+		e := VecSymMod(a.Coeffs, pl.CoefficientModulus)
+		a.SymModInPlace()
+		for i := 0; i < r.n; i++ {
+			if a.Coeffs[i].Cmp(e[i]) != 0 {
+				t.Errorf("expected %s at position [%d] but got %s", e[i].String(), i, a.Coeffs[i].String())
+				break
+			}
+		}
+	}
+}
+
+func TestPolyAllocations(t *testing.T) {
+	// Parameters.
+	p, err := params.New(params.PLBFV32)
+	if err != nil {
+		t.Error(err)
+	}
+	r := NewRing(p)
+	a, b := randomPoly(t, r), randomPoly(t, r)
+	c := r.NewPoly()
+	// Case: in-place additions and reductions allocate at most once per call,
+	// instead of once per coefficient, once the receiver is large enough.
+	c.Add(a, b).SymModInPlace()
+	n := testing.AllocsPerRun(10, func() {
+		c.Add(a, b).SymModInPlace()
+	})
+	if n > 1 {
+		t.Errorf("expected at most 1 allocation but got %f", n)
+	}
+	// Case: products reuse the temporary integers of the ring, for both the
+	// integer and the NTT products.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = big.NewInt(nttCoefficientModulus)
+	pn, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, r := range []*Ring{r, NewRing(pn)} {
+		a, b := randomPoly(t, r), randomPoly(t, r)
+		c := r.NewPoly()
+		if _, err := c.Mul(a, b); err != nil {
+			t.Error(err)
+		}
+		n := testing.AllocsPerRun(10, func() {
+			c.Mul(a, b)
+		})
+		if n > 1 {
+			t.Errorf("expected at most 1 allocation but got %f", n)
+		}
+	}
+}
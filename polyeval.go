@@ -0,0 +1,260 @@
+package scheme
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
+)
+
+// PolyEvalMethod identifies the algorithm used to evaluate a polynomial.
+type PolyEvalMethod int
+
+const (
+	Horner             PolyEvalMethod = iota // Horner's rule, with depth d-1.
+	PatersonStockmeyer                       // Paterson-Stockmeyer, with depth about log2(d).
+)
+
+// PolyEvalStats reports the cost of the evaluation of a polynomial on a ciphertext.
+type PolyEvalStats struct {
+	Method PolyEvalMethod // Algorithm chosen for the evaluation.
+	Depth  int            // Multiplicative depth, i.e., the longest chain of ciphertext multiplications.
+	Mults  int            // Number of ciphertext multiplications, each with a relinearization.
+	Noise  float64        // Noise estimate of the result (log2).
+	Budget float64        // Noise budget left (log2), which is negative when the decryption may fail.
+}
+
+// EvalPoly evaluates the polynomial c[0] + c[1]*x + ... + c[d]*x^d on the encrypted
+// message x. The coefficients are encoded with the codec of the ciphertext and
+// multiplied with PMult, so that only the powers of x need ciphertext
+// multiplications. Horner's rule is used when its depth is not larger than the
+// depth of Paterson-Stockmeyer, which is chosen for the larger degrees.
+func (e *Evaluator) EvalPoly(ct *Ciphertext, c []float64) (*Ciphertext, *PolyEvalStats, error) {
+	p := e.params
+	// Validate operands.
+	if err := validateCiphertext(ct, p); err != nil {
+		return nil, nil, err
+	}
+	if ct.Degree() != 1 {
+		return nil, nil, ErrCiphertextDegreeIsNotValid
+	}
+	if len(c) == 0 {
+		return nil, nil, ErrPolynomialIsNotValid
+	}
+	enc, err := e.encoder(ct.Codec)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Degree of the polynomial, without the leading zeros.
+	d := len(c) - 1
+	for d > 0 && c[d] == 0 {
+		d--
+	}
+	c = c[:d+1]
+	ev := &polyEval{e: e, x: ct, enc: enc, pw: map[int]*Ciphertext{1: ct}}
+	st := &PolyEvalStats{Method: Horner}
+	var r *Ciphertext
+	k := babySteps(d)
+	if hornerDepth(d) <= psDepth(c, k) {
+		r, st.Depth, err = ev.horner(c)
+	} else {
+		st.Method = PatersonStockmeyer
+		r, st.Depth, err = ev.patersonStockmeyer(c, k)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	st.Mults = ev.mults + len(ev.pw) - 1
+	st.Noise = r.Noise
+	st.Budget = p.NoiseCeiling() - r.Noise
+	return r, st, nil
+}
+
+// encoder returns the encoding function of a codec.
+func (e *Evaluator) encoder(codec Codec) (func(float64) []*big.Int, error) {
+	p := e.params
+	switch codec {
+	case CodecLaurent:
+		return laurent.New(p).Enc, nil
+	case CodecSIM2D:
+		sc, err := sim2d.New(p)
+		if err != nil {
+			return nil, err
+		}
+		return sc.Enc, nil
+	}
+	return nil, ErrCodecIsNotValid
+}
+
+// polyEval holds the state of the evaluation of a polynomial on x.
+type polyEval struct {
+	e     *Evaluator
+	x     *Ciphertext              // Encrypted message.
+	enc   func(float64) []*big.Int // Encoding of the coefficients.
+	pw    map[int]*Ciphertext      // Powers of x computed so far.
+	mults int                      // Ciphertext multiplications besides the powers of x.
+}
+
+// plain encodes a coefficient into a plaintext.
+func (ev *polyEval) plain(c float64) *Plaintext {
+	return NewPlaintext(ev.enc(c), ev.x.Codec, ev.e.params)
+}
+
+// addConst adds the coefficient c to a ciphertext, if it is not zero.
+func (ev *polyEval) addConst(ct *Ciphertext, c float64) (*Ciphertext, error) {
+	if c == 0 {
+		return ct, nil
+	}
+	return ev.e.SAdd(ct, ev.plain(c))
+}
+
+// horner evaluates the polynomial as (...(c[d]*x + c[d-1])*x + ...)*x + c[0].
+func (ev *polyEval) horner(c []float64) (*Ciphertext, int, error) {
+	d := len(c) - 1
+	if d == 0 {
+		return ev.baby(c)
+	}
+	// The first product is by the plaintext c[d].
+	acc, err := ev.e.PMult(ev.x, ev.plain(c[d]))
+	if err != nil {
+		return nil, 0, err
+	}
+	if acc, err = ev.addConst(acc, c[d-1]); err != nil {
+		return nil, 0, err
+	}
+	for i := d - 2; i >= 0; i-- {
+		if acc, err = ev.e.Mult(acc, ev.x); err != nil {
+			return nil, 0, err
+		}
+		ev.mults++
+		if acc, err = ev.addConst(acc, c[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return acc, hornerDepth(d), nil
+}
+
+// patersonStockmeyer evaluates the polynomial as q(x)*x^s + r(x), where s is the
+// largest k*2^j smaller than the number of coefficients, and q and r are evaluated
+// recursively until they have at most k coefficients. The powers x, ..., x^k
+// (baby steps) and x^(k*2^j) (giant steps) are computed once.
+func (ev *polyEval) patersonStockmeyer(c []float64, k int) (*Ciphertext, int, error) {
+	if len(c) <= k {
+		return ev.baby(c)
+	}
+	s := giantStep(len(c), k)
+	g, err := ev.e.pow(ev.pw, s)
+	if err != nil {
+		return nil, 0, err
+	}
+	var prod *Ciphertext
+	dp := ceilLog2(s)
+	if len(c[s:]) == 1 {
+		// The quotient is a constant.
+		if prod, err = ev.e.PMult(g, ev.plain(c[s])); err != nil {
+			return nil, 0, err
+		}
+	} else {
+		q, dq, err := ev.patersonStockmeyer(c[s:], k)
+		if err != nil {
+			return nil, 0, err
+		}
+		if prod, err = ev.e.Mult(q, g); err != nil {
+			return nil, 0, err
+		}
+		ev.mults++
+		dp = max(dp, dq) + 1
+	}
+	r, dr, err := ev.patersonStockmeyer(c[:s], k)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r, err = ev.e.Add(prod, r); err != nil {
+		return nil, 0, err
+	}
+	return r, max(dp, dr), nil
+}
+
+// baby evaluates c[0] + c[1]*x + ... + c[l]*x^l as a sum of plaintext products
+// of the powers of x.
+func (ev *polyEval) baby(c []float64) (*Ciphertext, int, error) {
+	// Encryption of zero, whose noise estimate is -Inf.
+	acc, err := ev.e.SMult(ev.x, new(big.Int))
+	if err != nil {
+		return nil, 0, err
+	}
+	d := 0
+	for i := 1; i < len(c); i++ {
+		if c[i] == 0 {
+			continue
+		}
+		xi, err := ev.e.pow(ev.pw, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		prod, err := ev.e.PMult(xi, ev.plain(c[i]))
+		if err != nil {
+			return nil, 0, err
+		}
+		if acc, err = ev.e.Add(acc, prod); err != nil {
+			return nil, 0, err
+		}
+		d = max(d, ceilLog2(i))
+	}
+	acc, err = ev.addConst(acc, c[0])
+	return acc, d, err
+}
+
+// hornerDepth returns the multiplicative depth of Horner's rule for degree d.
+func hornerDepth(d int) int {
+	return max(d-1, 0)
+}
+
+// psDepth returns the multiplicative depth of Paterson-Stockmeyer with k baby steps,
+// following the same recursion as polyEval.patersonStockmeyer.
+func psDepth(c []float64, k int) int {
+	if len(c) <= k {
+		d := 0
+		for i := 1; i < len(c); i++ {
+			if c[i] != 0 {
+				d = max(d, ceilLog2(i))
+			}
+		}
+		return d
+	}
+	s := giantStep(len(c), k)
+	dp := ceilLog2(s)
+	if len(c[s:]) > 1 {
+		dp = max(dp, psDepth(c[s:], k)) + 1
+	}
+	return max(dp, psDepth(c[:s], k))
+}
+
+// babySteps returns the number of baby steps for degree d, i.e., the power of 2
+// closest to sqrt(d+1) from above.
+func babySteps(d int) int {
+	return 1 << ((ceilLog2(d+1) + 1) / 2)
+}
+
+// giantStep returns the largest k*2^j smaller than l.
+func giantStep(l, k int) int {
+	s := k
+	for 2*s < l {
+		s *= 2
+	}
+	return s
+}
+
+// ceilLog2 returns ceil(log2(i)) for i >= 1, i.e., the depth of x^i.
+func ceilLog2(i int) int {
+	return bits.Len(uint(i - 1))
+}
+
+// max returns the largest of two integers.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,138 @@
+package scheme
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
+)
+
+// evalPlain evaluates a polynomial on x with Horner's rule.
+func evalPlain(c []float64, x float64) float64 {
+	r := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		r = r*x + c[i]
+	}
+	return r
+}
+
+func TestBFVEvalPoly(t *testing.T) {
+	// Create parameters with q = 2^256 - 189 and t = 2^20 + 7, whose noise budget
+	// and plaintext space support a few multiplications.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	pl.CoefficientModulus.Sub(pl.CoefficientModulus, big.NewInt(189))
+	pl.DecryptionModulus = big.NewInt(1<<20 + 7)
+	p, cip, eval := newTestCipher(t, pl)
+	// SIM2D codec.
+	sc, err := sim2d.New(p)
+	if err != nil {
+		t.Error(err)
+	}
+	// Message (1.5).
+	m := 1.5
+	c, err := cip.Enc(NewPlaintext(sc.Enc(m), CodecSIM2D, p))
+	if err != nil {
+		t.Error(err)
+	}
+	tests := []struct {
+		coeffs []float64
+		method PolyEvalMethod
+		depth  int
+	}{
+		// Case: constant polynomial.
+		{[]float64{3}, Horner, 0},
+		// Case: linear polynomial with leading zeros.
+		{[]float64{0.5, -2, 0, 0}, Horner, 0},
+		// Case: quadratic polynomial.
+		{[]float64{0.5, 2, 0.25}, Horner, 1},
+		// Case: quintic polynomial with zero coefficients.
+		{[]float64{1, -0.5, 0, 0.125, 0, 0.25}, PatersonStockmeyer, 3},
+	}
+	for _, tt := range tests {
+		cr, st, err := eval.EvalPoly(c, tt.coeffs)
+		if err != nil {
+			t.Error(err)
+		}
+		if st.Method != tt.method || st.Depth != tt.depth {
+			t.Errorf("expected method %d with depth %d for %v but got method %d with depth %d", tt.method, tt.depth, tt.coeffs, st.Method, st.Depth)
+		}
+		if st.Noise != cr.Noise || st.Budget <= 0 {
+			t.Errorf("expected noise estimate %f within the budget but got %f with budget %f", cr.Noise, st.Noise, st.Budget)
+		}
+		// Decrypt.
+		crd, err := cip.Dec(cr)
+		if err != nil {
+			t.Error(err)
+		}
+		// SIM2D decode.
+		mrd, err := sc.Dec(crd.Value)
+		if err != nil {
+			t.Error(err)
+		}
+		// Check result.
+		if mr := evalPlain(tt.coeffs, m); math.Abs(mrd-mr) > 1e-9*math.Abs(mr) {
+			t.Errorf("expected %f for %v at %f, but got %f", mr, tt.coeffs, m, mrd)
+		}
+	}
+	// Case: empty polynomial.
+	if _, _, err := eval.EvalPoly(c, nil); err != ErrPolynomialIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrPolynomialIsNotValid, err)
+	}
+	// Case: ciphertexts without codec.
+	c.Codec = CodecNone
+	if _, _, err := eval.EvalPoly(c, []float64{1, 2}); err != ErrCodecIsNotValid {
+		t.Errorf("expected error %s but got %v", ErrCodecIsNotValid, err)
+	}
+}
+
+func TestHERatioEvalPoly(t *testing.T) {
+	// Create parameters with q = 2^256 - 189, whose noise budget supports a few multiplications.
+	pl := params.PLHERatio16
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	pl.CoefficientModulus.Sub(pl.CoefficientModulus, big.NewInt(189))
+	p, cip, eval := newTestCipher(t, pl)
+	// Laurent codec.
+	lc := laurent.New(p)
+	// Case: polynomial approximation of exp(x) of degree 4 on message (0.75).
+	m := 0.75
+	coeffs := []float64{1, 1, 0.5, 0.125, 0.0625}
+	c, err := cip.Enc(NewPlaintext(lc.Enc(m), CodecLaurent, p))
+	if err != nil {
+		t.Error(err)
+	}
+	cr, st, err := eval.EvalPoly(c, coeffs)
+	if err != nil {
+		t.Error(err)
+	}
+	if st.Method != PatersonStockmeyer || st.Depth != 2 {
+		t.Errorf("expected method %d with depth %d but got method %d with depth %d", PatersonStockmeyer, 2, st.Method, st.Depth)
+	}
+	// Decrypt.
+	mrb, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	// Decode.
+	r := lc.Dec(mrb.Value)
+	// Check result (up to the precision of the fractional digits of the Laurent codec).
+	if er := evalPlain(coeffs, m); math.Abs(r-er) > 1e-6*math.Abs(er) {
+		t.Errorf("expected %f for %v at %f, but got %f", er, coeffs, m, r)
+	}
+}
+
+func TestPolyEvalDepth(t *testing.T) {
+	// Case: Paterson-Stockmeyer has the minimal depth ceil(log2(d)) for dense polynomials.
+	for d := 1; d <= 64; d++ {
+		c := make([]float64, d+1)
+		for i := 0; i < len(c); i++ {
+			c[i] = 1
+		}
+		if pd := psDepth(c, babySteps(d)); pd != ceilLog2(d) {
+			t.Errorf("expected depth %d for degree %d but got %d", ceilLog2(d), d, pd)
+		}
+	}
+}
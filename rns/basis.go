@@ -0,0 +1,64 @@
+package rns
+
+import (
+	"math"
+	"math/big"
+)
+
+// basisExtender converts polynomials from the basis of a ring A into another
+// basis B through the fast base conversion of Halevi, Polyakov and Shoup (HPS).
+// A coefficient x in [-A/2, A/2) is written as sum(y_i * A/a_i) - v*A, where the
+// overflow v = round(sum(y_i/a_i)) is computed in float64. With k primes in A,
+// the rounding error of the sum is below k*(k+2)*2^-53, so the conversion is
+// exact for |x| < A*(1/2 - k*(k+2)*2^-53), and may give x - A or x + A closer to
+// the bounds. In scale, such an error changes the result by at most 1.
+type basisExtender struct {
+	from   []uint64   // Primes of the source basis.
+	to     []uint64   // Moduli of the target basis.
+	hatInv []uint64   // (A/a_i)^(-1) mod a_i.
+	inv    []float64  // 1/a_i.
+	hatMod [][]uint64 // (A/a_i) mod b_j, indexed by [j][i].
+	modMod []uint64   // A mod b_j.
+}
+
+// newBasisExtender precomputes the constants to convert from the basis of ring a into moduli b.
+func newBasisExtender(a *Ring, b []uint64) *basisExtender {
+	be := &basisExtender{from: a.moduli, to: b, hatInv: a.hatInv}
+	for _, q := range a.moduli {
+		be.inv = append(be.inv, 1/float64(q))
+	}
+	m := new(big.Int)
+	for _, p := range b {
+		bp := new(big.Int).SetUint64(p)
+		row := make([]uint64, len(a.moduli))
+		for i := range a.moduli {
+			row[i] = m.Mod(a.hat[i], bp).Uint64()
+		}
+		be.hatMod = append(be.hatMod, row)
+		be.modMod = append(be.modMod, m.Mod(a.modulus, bp).Uint64())
+	}
+	return be
+}
+
+// convert writes the limbs of a (basis A) converted into the basis B into out.
+func (be *basisExtender) convert(a, out [][]uint64) {
+	y := make([]uint64, len(be.from))
+	n := len(a[0])
+	for k := 0; k < n; k++ {
+		// y_i = a_i * (A/a_i)^(-1) mod a_i, so that a = sum(y_i * A/a_i) - v * A.
+		s := 0.0
+		for i, q := range be.from {
+			y[i] = mulMod(a[i][k], be.hatInv[i], q)
+			s += float64(y[i]) * be.inv[i]
+		}
+		// Rounding (instead of truncating) gives the symmetric representative.
+		v := uint64(math.Round(s))
+		for j, p := range be.to {
+			acc := uint64(0)
+			for i := range be.from {
+				acc = addMod(acc, mulMod(y[i], be.hatMod[j][i], p), p)
+			}
+			out[j][k] = subMod(acc, mulMod(v, be.modMod[j], p), p)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rns
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+)
+
+func TestBasisExtender(t *testing.T) {
+	// Case: conversion into another basis keeps the symmetric representative.
+	n := 32
+	primes, err := NTTPrimes(60, n, 6)
+	if err != nil {
+		t.Error(err)
+	}
+	from, err := NewRing(n, primes[:3])
+	if err != nil {
+		t.Error(err)
+	}
+	to, err := NewRing(n, primes[3:])
+	if err != nil {
+		t.Error(err)
+	}
+	be := newBasisExtender(from, to.Moduli())
+	// Random values in [-Q/2, Q/2).
+	o := new(oracle.Oracle)
+	coeffs := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		r, err := o.RandInt(-1<<61, 1<<61, 3)
+		if err != nil {
+			t.Error(err)
+		}
+		coeffs[i] = new(big.Int).Mul(r[0], r[1])
+		coeffs[i].Mul(coeffs[i], r[2])
+		coeffs[i].Rsh(coeffs[i], 6)
+	}
+	a, b := from.NewPoly(), to.NewPoly()
+	from.SetBigInt(coeffs, a)
+	be.convert(a.Coeffs, b.Coeffs)
+	rc := to.BigInt(b)
+	for i := 0; i < n; i++ {
+		if rc[i].Cmp(coeffs[i]) != 0 {
+			t.Errorf("expected %s at position [%d] but got %s", coeffs[i].String(), i, rc[i].String())
+			break
+		}
+	}
+}
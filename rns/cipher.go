@@ -0,0 +1,69 @@
+package rns
+
+import (
+	"math/big"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/utils"
+)
+
+// Cipher is the structure that encrypts encoded messages into RNS ciphertexts,
+// and decrypts them back into code messages.
+type Cipher struct {
+	kc *Keychain // Structure for key generation and random sources.
+}
+
+// NewCipher creates a new cipher with a given source for randomness in the keychain.
+func NewCipher(kc *Keychain) (*Cipher, error) {
+	return &Cipher{kc: kc}, nil
+}
+
+// Enc encrypts an encoded message into (pk0*u + e0 + delta*m, pk1*u + e1).
+func (cip *Cipher) Enc(m []*big.Int) ([]*Poly, error) {
+	// Parameters.
+	p := cip.kc.Params
+	r := p.q
+	// Random ternary polynomial.
+	u, err := cip.kc.ternary()
+	if err != nil {
+		return nil, err
+	}
+	// DeltaM.
+	dm := r.NewPoly()
+	r.SetBigInt(m, dm)
+	r.MulScalar(dm, p.delta, dm)
+	// Multiplication by the public key.
+	c0, c1 := r.NewPoly(), r.NewPoly()
+	p.mul(r, cip.kc.PK[0], u, c0)
+	p.mul(r, cip.kc.PK[1], u, c1)
+	r.Add(c0, cip.kc.gaussian(), c0)
+	r.Add(c1, cip.kc.gaussian(), c1)
+	r.Add(c0, dm, c0)
+	return []*Poly{c0, c1}, nil
+}
+
+// Dec decrypts a ciphertext into a coded message, i.e., round(t/Q * [c0 + c1*s]_Q) mod t.
+func (cip *Cipher) Dec(c []*Poly) ([]*big.Int, error) {
+	if len(c) != 2 {
+		return nil, ErrCiphertextIsNotValid
+	}
+	// Parameters.
+	p := cip.kc.Params
+	r := p.q
+	t := p.t
+	// x = c0 + c1*s.
+	x := r.NewPoly()
+	p.mul(r, c[1], cip.kc.SK, x)
+	r.Add(x, c[0], x)
+	// With z = [t*x]_Q in the symmetric range, round(t*x/Q) = (t*x - z)/Q,
+	// which is equal to -z * Q^(-1) modulo t.
+	r.MulScalar(x, p.tQ, x)
+	z := [][]uint64{make([]uint64, r.n)}
+	p.qToT.convert(x.Coeffs, z)
+	m := make([]*big.Int, r.n)
+	bt := new(big.Int).SetUint64(t)
+	for i := 0; i < r.n; i++ {
+		v := mulMod(subMod(0, z[0][i], t), p.qInvT, t)
+		m[i] = utils.SymMod(new(big.Int).SetUint64(v), bt)
+	}
+	return m, nil
+}
@@ -0,0 +1,84 @@
+package rns
+
+import (
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
+)
+
+// newTestKeychain creates RNS parameters with the given number of 50-bit primes and a new keychain.
+func newTestKeychain(t *testing.T, pl params.Literal, limbs int) *Keychain {
+	p, err := params.New(pl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	primes, err := NTTPrimes(50, p.Size(), limbs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := NewParams(p, primes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc, err := NewKeychain(new(oracle.Oracle), rp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kc
+}
+
+func TestBFVEncDec(t *testing.T) {
+	// Case: encrypt and decrypt message 0 (12345.678) with a 150-bit coefficient modulus.
+	kc := newTestKeychain(t, params.PLBFV32, 3)
+	// SIM2D codec.
+	sc, err := sim2d.New(kc.Params.Vars())
+	if err != nil {
+		t.Error(err)
+	}
+	// Cipher.
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	c, err := cip.Enc(sc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(c)
+	if err != nil {
+		t.Error(err)
+	}
+	r, err := sc.Dec(m)
+	if err != nil {
+		t.Error(err)
+	}
+	if r != params.M0 {
+		t.Errorf("expected %f but got %f", params.M0, r)
+	}
+}
+
+func TestHERatioEncDec(t *testing.T) {
+	// Case: encrypt and decrypt message 0 (12345.678) with a 150-bit coefficient modulus.
+	kc := newTestKeychain(t, params.PLHERatio16, 3)
+	// Laurent codec.
+	lc := laurent.New(kc.Params.Vars())
+	// Cipher.
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	c, err := cip.Enc(lc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(c)
+	if err != nil {
+		t.Error(err)
+	}
+	if r := lc.Dec(m); r != params.M0 {
+		t.Errorf("expected %f but got %f", params.M0, r)
+	}
+}
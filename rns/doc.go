@@ -0,0 +1,12 @@
+// Package rns implements a residue number system (RNS) backend for the BFV
+// and HERatio schemes. The coefficient modulus is the product of word-sized
+// NTT-friendly primes and polynomials are stored as one row of uint64
+// coefficients (limb) per prime, so that the arithmetic is done limb-wise
+// without big integers and the modulus is not limited to 64 bits.
+//
+// The backend is separate from the types of the scheme package: it has its own
+// Params, Keychain, Cipher and Evaluator, which encrypt, add, multiply and
+// relinearize polynomials of codec messages, while scheme.Cipher and
+// scheme.Evaluator keep the big-integer representation. The ciphertexts of the
+// two backends are not interchangeable.
+package rns
@@ -0,0 +1,18 @@
+package rns
+
+import "errors"
+
+var (
+	ErrModuliIsEmpty                 = errors.New("at least one modulus must be given")
+	ErrModulusIsNotNTTFriendly       = errors.New("modulus should be a prime q < 2^62 with q = 1 mod 2n")
+	ErrModuliAreNotDistinct          = errors.New("moduli should be distinct")
+	ErrDegreeIsNotAPowerOfTwo        = errors.New("size of the ring should be a power of 2")
+	ErrNotEnoughPrimes               = errors.New("not enough NTT-friendly primes for the given bit size")
+	ErrDecryptionModulusIsNotValid   = errors.New("decryption modulus should be greater than 1 and smaller than 2^62")
+	ErrDecryptionModulusIsNotCoprime = errors.New("decryption modulus should be coprime with the coefficient modulus")
+	ErrRelinearizationBaseIsNotValid = errors.New("relinearization expansion base should be greater than 1")
+	ErrSchemeIsNotValid              = errors.New("scheme is not valid")
+	ErrCiphertextIsNotValid          = errors.New("ciphertext should have 2 components")
+	ErrCiphertextIsNotRelinearizable = errors.New("only ciphertexts with 3 components can be relinearized")
+	ErrCiphertextsHaveDifferentSizes = errors.New("ciphertexts should have the same number of components")
+)
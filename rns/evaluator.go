@@ -0,0 +1,127 @@
+package rns
+
+// Evaluator has the functions that execute the mathematical operations on RNS ciphertexts.
+type Evaluator struct {
+	keychain *Keychain
+}
+
+// NewEvaluator creates a new Evaluator.
+func NewEvaluator(kc *Keychain) *Evaluator {
+	return &Evaluator{keychain: kc}
+}
+
+// Add executes the addition of two ciphertexts.
+func (e *Evaluator) Add(c0, c1 []*Poly) ([]*Poly, error) {
+	if len(c0) != len(c1) {
+		return nil, ErrCiphertextsHaveDifferentSizes
+	}
+	r := e.keychain.Params.q
+	c := make([]*Poly, len(c0))
+	for i := 0; i < len(c); i++ {
+		c[i] = r.NewPoly()
+		r.Add(c0[i], c1[i], c[i])
+	}
+	return c, nil
+}
+
+// Mult executes the multiplication of two ciphertexts.
+func (e *Evaluator) Mult(ct0, ct1 []*Poly) ([]*Poly, error) {
+	if len(ct0) != 2 || len(ct1) != 2 {
+		return nil, ErrCiphertextIsNotValid
+	}
+	m := e.multPrime(ct0, ct1)
+	return e.relinearize(m)
+}
+
+// multPrime calculates round(t/Q * ct0 x ct1) limb-wise. The tensor product is
+// computed exactly in the extended basis Q*P, then scaled down into P and
+// converted back into Q.
+func (e *Evaluator) multPrime(ct0, ct1 []*Poly) []*Poly {
+	p := e.keychain.Params
+	rq, rp := p.q, p.p
+	// Extension of the components into the auxiliary basis.
+	ext := func(a *Poly) *Poly {
+		b := rp.NewPoly()
+		p.qToP.convert(a.Coeffs, b.Coeffs)
+		return b
+	}
+	a0, a1, b0, b1 := ext(ct0[0]), ext(ct0[1]), ext(ct1[0]), ext(ct1[1])
+	// Tensor product in both bases.
+	dq := p.tensor(rq, ct0[0], ct0[1], ct1[0], ct1[1])
+	dp := p.tensor(rp, a0, a1, b0, b1)
+	// Scaling.
+	c := make([]*Poly, 3)
+	for i := 0; i < len(c); i++ {
+		c[i] = p.scale(dq[i], dp[i])
+	}
+	return c
+}
+
+// tensor returns (x0*y0, x0*y1 + x1*y0, x1*y1) in the given ring.
+func (p *Params) tensor(r *Ring, x0, x1, y0, y1 *Poly) []*Poly {
+	d := []*Poly{r.NewPoly(), r.NewPoly(), r.NewPoly()}
+	tmp := r.NewPoly()
+	p.mul(r, x0, y0, d[0])
+	p.mul(r, x0, y1, d[1])
+	p.mul(r, x1, y0, tmp)
+	r.Add(d[1], tmp, d[1])
+	p.mul(r, x1, y1, d[2])
+	return d
+}
+
+// scale returns round(t*x/Q) in the basis Q, given x in both bases Q and P.
+func (p *Params) scale(xq, xp *Poly) *Poly {
+	rq, rp := p.q, p.p
+	// z = [t*x]_Q in the symmetric range, converted into P.
+	z := rq.NewPoly()
+	rq.MulScalar(xq, p.tQ, z)
+	zp := rp.NewPoly()
+	p.qToP.convert(z.Coeffs, zp.Coeffs)
+	// round(t*x/Q) = (t*x - z)/Q, which is an exact division in P.
+	y := rp.NewPoly()
+	rp.MulScalar(xp, p.tP, y)
+	rp.Sub(y, zp, y)
+	rp.MulScalar(y, p.qInvP, y)
+	// Back to Q.
+	c := rq.NewPoly()
+	p.pToQ.convert(y.Coeffs, c.Coeffs)
+	return c
+}
+
+// relinearize turns a ciphertext with 3 components into one with 2 components.
+// The last component is decomposed limb-wise: d2 = sum([d2 * (Q/q_i)^(-1)]_q_i * Q/q_i),
+// and each term is further expanded in the relinearization expansion base.
+func (e *Evaluator) relinearize(d []*Poly) ([]*Poly, error) {
+	if len(d) != 3 {
+		return nil, ErrCiphertextIsNotRelinearizable
+	}
+	p := e.keychain.Params
+	r := p.q
+	w := p.w
+	ek := e.keychain.EK
+	c0, c1 := r.Copy(d[0]), r.Copy(d[1])
+	g, prod := r.NewPoly(), r.NewPoly()
+	v := make([]uint64, r.n)
+	k := 0
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			v[j] = mulMod(d[2].Coeffs[i][j], r.hatInv[i], q)
+		}
+		for l := 0; l < p.digits[i]; l++ {
+			// Digit l of the expansion, lifted to every prime.
+			for j := 0; j < r.n; j++ {
+				dg := v[j] % w
+				v[j] /= w
+				for m, qm := range r.moduli {
+					g.Coeffs[m][j] = dg % qm
+				}
+			}
+			p.mul(r, g, ek[k][0], prod)
+			r.Add(c0, prod, c0)
+			p.mul(r, g, ek[k][1], prod)
+			r.Add(c1, prod, c1)
+			k++
+		}
+	}
+	return []*Poly{c0, c1}, nil
+}
@@ -0,0 +1,204 @@
+package rns
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/laurent"
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+	"github.com/Algemetric/HERatio/Implementation/Golang/sim2d"
+)
+
+func TestBFVAdd(t *testing.T) {
+	// Case: message 0 (12345.678) + message 1 (947.1273).
+	kc := newTestKeychain(t, params.PLBFV32, 3)
+	sc, err := sim2d.New(kc.Params.Vars())
+	if err != nil {
+		t.Error(err)
+	}
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	eval := NewEvaluator(kc)
+	c0, err := cip.Enc(sc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(sc.Enc(params.M1))
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eval.Add(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	r, err := sc.Dec(m)
+	if err != nil {
+		t.Error(err)
+	}
+	if mr := params.M0 + params.M1; r != mr {
+		t.Errorf("expected %f for %f + %f, but got %f", mr, params.M0, params.M1, r)
+	}
+}
+
+func TestHERatioAdd(t *testing.T) {
+	// Case: message 0 (12345.678) + message 1 (947.1273) = 13,292.8053.
+	kc := newTestKeychain(t, params.PLHERatio16, 3)
+	lc := laurent.New(kc.Params.Vars())
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	eval := NewEvaluator(kc)
+	c0, err := cip.Enc(lc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(lc.Enc(params.M1))
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eval.Add(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	if er, r := 13292.8053, lc.Dec(m); r != er {
+		t.Errorf("expected %f for %f + %f, but got %f", er, params.M0, params.M1, r)
+	}
+}
+
+func TestBFVMult(t *testing.T) {
+	// Case: message 0 (12345.678) x message 1 (947.1273).
+	kc := newTestKeychain(t, params.PLBFV32, 3)
+	sc, err := sim2d.New(kc.Params.Vars())
+	if err != nil {
+		t.Error(err)
+	}
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	eval := NewEvaluator(kc)
+	c0, err := cip.Enc(sc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(sc.Enc(params.M1))
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eval.Mult(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	r, err := sc.Dec(m)
+	if err != nil {
+		t.Error(err)
+	}
+	if mr := params.M0 * params.M1; r != mr {
+		t.Errorf("expected %f for %f x %f, but got %f", mr, params.M0, params.M1, r)
+	}
+}
+
+func TestHERatioMult(t *testing.T) {
+	// Case: message 0 (12345.678) x message 1 (947.1273).
+	kc := newTestKeychain(t, params.PLHERatio16, 3)
+	lc := laurent.New(kc.Params.Vars())
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	eval := NewEvaluator(kc)
+	c0, err := cip.Enc(lc.Enc(params.M0))
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(lc.Enc(params.M1))
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eval.Mult(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	if mr, r := params.M0*params.M1, lc.Dec(m); r != mr {
+		t.Errorf("expected %f for %f x %f, but got %f", mr, params.M0, params.M1, r)
+	}
+}
+
+// TestBFVMult2048 tests the multiplication of 2 ciphertexts with a 150-bit coefficient modulus.
+func TestBFVMult2048(t *testing.T) {
+	kc := newTestKeychain(t, params.PLBFV2048, 3)
+	p := kc.Params.Vars()
+	dm := p.DecryptionModulus().Int64()
+	cip, err := NewCipher(kc)
+	if err != nil {
+		t.Error(err)
+	}
+	eval := NewEvaluator(kc)
+	// Random messages modulo t.
+	o := new(oracle.Oracle)
+	m0, err := o.RandInt(-dm/2, dm/2, p.Size())
+	if err != nil {
+		t.Error(err)
+	}
+	m1, err := o.RandInt(-dm/2, dm/2, p.Size())
+	if err != nil {
+		t.Error(err)
+	}
+	c0, err := cip.Enc(m0)
+	if err != nil {
+		t.Error(err)
+	}
+	c1, err := cip.Enc(m1)
+	if err != nil {
+		t.Error(err)
+	}
+	cr, err := eval.Mult(c0, c1)
+	if err != nil {
+		t.Error(err)
+	}
+	m, err := cip.Dec(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	// Expected negacyclic product modulo t.
+	n := p.Size()
+	for k := 0; k < n; k++ {
+		e := int64(0)
+		for i := 0; i < n; i++ {
+			if j := k - i; j >= 0 {
+				e += m0[i].Int64() * m1[j].Int64()
+			} else {
+				e -= m0[i].Int64() * m1[j+n].Int64()
+			}
+		}
+		eb := big.NewInt(e)
+		eb.Mod(eb, big.NewInt(dm))
+		if eb.Cmp(big.NewInt(dm/2)) > 0 {
+			eb.Sub(eb, big.NewInt(dm))
+		}
+		if eb.Cmp(m[k]) != 0 {
+			t.Errorf("expected %s at position [%d] but got %s", eb.String(), k, m[k].String())
+			break
+		}
+	}
+}
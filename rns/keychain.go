@@ -0,0 +1,124 @@
+package rns
+
+import (
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+)
+
+// Keychain manages the secret, public, and evaluation keys in RNS representation.
+type Keychain struct {
+	O      oracle.Randomizer // Random source.
+	SK     *Poly             // Secret key.
+	PK     []*Poly           // Public key.
+	EK     [][]*Poly         // Evaluation key (one pair for each prime and digit).
+	Params *Params           // Parameters.
+}
+
+// NewKeychain instantiates a new Keychain with a secret, public and evaluation keys.
+func NewKeychain(o oracle.Randomizer, p *Params) (*Keychain, error) {
+	// Error variable.
+	var err error
+	// New keychain.
+	kc := &Keychain{O: o, Params: p}
+	// Secret key.
+	kc.SK, err = kc.GenSK()
+	if err != nil {
+		return nil, err
+	}
+	// Public key.
+	kc.PK, err = kc.GenPK()
+	if err != nil {
+		return nil, err
+	}
+	// Evaluation key.
+	kc.EK, err = kc.GenEK()
+	if err != nil {
+		return nil, err
+	}
+	return kc, nil
+}
+
+// GenSK generates the secret key with coefficients in {-1, 0, 1}.
+func (kc *Keychain) GenSK() (*Poly, error) {
+	return kc.ternary()
+}
+
+// GenPK generates the public key (-a*s + e, a).
+func (kc *Keychain) GenPK() ([]*Poly, error) {
+	r := kc.Params.q
+	a, err := kc.uniform()
+	if err != nil {
+		return nil, err
+	}
+	b := r.NewPoly()
+	kc.Params.mul(r, a, kc.SK, b)
+	r.Sub(kc.gaussian(), b, b)
+	return []*Poly{b, a}, nil
+}
+
+// GenEK generates the evaluation key. For each prime q_i and digit j of the
+// relinearization expansion the key is (-a*s + e + w^j * (Q/q_i) * s^2, a).
+func (kc *Keychain) GenEK() ([][]*Poly, error) {
+	p := kc.Params
+	r := p.q
+	w := p.w
+	// Square of the secret key.
+	s2 := r.NewPoly()
+	p.mul(r, kc.SK, kc.SK, s2)
+	var ek [][]*Poly
+	for i, q := range r.moduli {
+		// Q/q_i is zero modulo every prime except q_i.
+		g := r.Residues(r.hat[i])[i]
+		for j := 0; j < p.digits[i]; j++ {
+			a, err := kc.uniform()
+			if err != nil {
+				return nil, err
+			}
+			b := r.NewPoly()
+			p.mul(r, a, kc.SK, b)
+			r.Sub(kc.gaussian(), b, b)
+			for k := 0; k < r.n; k++ {
+				b.Coeffs[i][k] = addMod(b.Coeffs[i][k], mulMod(s2.Coeffs[i][k], g, q), q)
+			}
+			ek = append(ek, []*Poly{b, a})
+			g = mulMod(g, w%q, q)
+		}
+	}
+	return ek, nil
+}
+
+// ternary samples a polynomial with coefficients in {-1, 0, 1}.
+func (kc *Keychain) ternary() (*Poly, error) {
+	r := kc.Params.q
+	rn, err := kc.O.RandInt(-1, 2, r.n)
+	if err != nil {
+		return nil, err
+	}
+	p := r.NewPoly()
+	r.SetBigInt(rn, p)
+	return p, nil
+}
+
+// uniform samples a polynomial with coefficients uniformly distributed modulo Q,
+// which amounts to sampling each limb uniformly modulo its prime.
+func (kc *Keychain) uniform() (*Poly, error) {
+	r := kc.Params.q
+	p := r.NewPoly()
+	for i, q := range r.moduli {
+		rn, err := kc.O.RandInt(0, int64(q), r.n)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < r.n; j++ {
+			p.Coeffs[i][j] = rn[j].Uint64()
+		}
+	}
+	return p, nil
+}
+
+// gaussian samples a polynomial from the discrete Gaussian distribution.
+func (kc *Keychain) gaussian() *Poly {
+	r := kc.Params.q
+	p := r.NewPoly()
+	r.SetBigInt(kc.O.NormDist(r.n), p)
+	return p
+}
@@ -0,0 +1,148 @@
+package rns
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// nttTable holds the precomputed twiddle factors of the negacyclic
+// number-theoretic transform for one modulus (limb) of the ring.
+type nttTable struct {
+	q      uint64   // Prime modulus.
+	psi    []uint64 // Powers of a primitive 2n-th root of unity in bit-reversed order.
+	psiInv []uint64 // Powers of the inverse root in bit-reversed order.
+	nInv   uint64   // Inverse of n modulo q.
+}
+
+// newNTTTable computes the twiddle factors for size n and modulus q.
+func newNTTTable(n int, q uint64) (*nttTable, error) {
+	if !isNTTFriendly(n, q) {
+		return nil, ErrModulusIsNotNTTFriendly
+	}
+	// Search for a primitive 2n-th root of unity psi, i.e., psi^n = -1 (mod q).
+	e := (q - 1) / uint64(2*n)
+	var psi uint64
+	for g := uint64(2); ; g++ {
+		psi = powMod(g, e, q)
+		if powMod(psi, uint64(n), q) == q-1 {
+			break
+		}
+	}
+	psiInv := powMod(psi, q-2, q)
+	// Powers of psi and its inverse in bit-reversed order.
+	t := &nttTable{q: q, psi: make([]uint64, n), psiInv: make([]uint64, n)}
+	logN := bits.Len(uint(n)) - 1
+	pw, pwInv := uint64(1), uint64(1)
+	for i := 0; i < n; i++ {
+		j := bits.Reverse(uint(i)) >> (bits.UintSize - logN)
+		t.psi[j] = pw
+		t.psiInv[j] = pwInv
+		pw = mulMod(pw, psi, q)
+		pwInv = mulMod(pwInv, psiInv, q)
+	}
+	t.nInv = powMod(uint64(n), q-2, q)
+	return t, nil
+}
+
+// forward transforms the coefficients into the evaluation domain in place (Cooley-Tukey).
+func (t *nttTable) forward(a []uint64) {
+	n, q := len(a), t.q
+	for m, h := 1, n/2; m < n; m, h = 2*m, h/2 {
+		for i := 0; i < m; i++ {
+			s := t.psi[m+i]
+			for j := 2 * i * h; j < 2*i*h+h; j++ {
+				v := mulMod(a[j+h], s, q)
+				a[j+h] = subMod(a[j], v, q)
+				a[j] = addMod(a[j], v, q)
+			}
+		}
+	}
+}
+
+// inverse transforms the values back into the coefficient domain in place (Gentleman-Sande).
+func (t *nttTable) inverse(a []uint64) {
+	n, q := len(a), t.q
+	for m, h := n/2, 1; m >= 1; m, h = m/2, 2*h {
+		for i := 0; i < m; i++ {
+			s := t.psiInv[m+i]
+			for j := 2 * i * h; j < 2*i*h+h; j++ {
+				u := a[j]
+				a[j] = addMod(u, a[j+h], q)
+				a[j+h] = mulMod(subMod(u, a[j+h], q), s, q)
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		a[i] = mulMod(a[i], t.nInv, q)
+	}
+}
+
+// isNTTFriendly reports whether q is a prime smaller than 2^62 with q = 1 (mod 2n).
+func isNTTFriendly(n int, q uint64) bool {
+	if q >= 1<<62 || q < 3 {
+		return false
+	}
+	if (q-1)%uint64(2*n) != 0 {
+		return false
+	}
+	return new(big.Int).SetUint64(q).ProbablyPrime(20)
+}
+
+// NTTPrimes returns count distinct NTT-friendly primes (q = 1 mod 2n) smaller than 2^bitSize,
+// starting from the greatest one.
+func NTTPrimes(bitSize, n, count int) ([]uint64, error) {
+	if n < 1 || n&(n-1) != 0 {
+		return nil, ErrDegreeIsNotAPowerOfTwo
+	}
+	if bitSize > 62 {
+		return nil, ErrModulusIsNotNTTFriendly
+	}
+	var primes []uint64
+	m := uint64(2 * n)
+	// Greatest candidate q = 1 (mod 2n) smaller than 2^bitSize.
+	for q := ((uint64(1)<<bitSize)-1)/m*m + 1; q > m && len(primes) < count; q -= m {
+		if isNTTFriendly(n, q) {
+			primes = append(primes, q)
+		}
+	}
+	if len(primes) < count {
+		return nil, ErrNotEnoughPrimes
+	}
+	return primes, nil
+}
+
+// mulMod returns a * b mod q.
+func mulMod(a, b, q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, q)
+}
+
+// addMod returns a + b mod q for a, b < q.
+func addMod(a, b, q uint64) uint64 {
+	s := a + b
+	if s >= q {
+		s -= q
+	}
+	return s
+}
+
+// subMod returns a - b mod q for a, b < q.
+func subMod(a, b, q uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + q - b
+}
+
+// powMod returns a^e mod q.
+func powMod(a, e, q uint64) uint64 {
+	r := uint64(1)
+	a %= q
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = mulMod(r, a, q)
+		}
+		a = mulMod(a, a, q)
+	}
+	return r
+}
@@ -0,0 +1,134 @@
+package rns
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/params"
+)
+
+// auxPrimeSize is the bit size of the primes of the auxiliary basis.
+const auxPrimeSize = 60
+
+// Params organizes the scheme parameters along with the RNS basis of the
+// coefficient modulus (Q) and the auxiliary basis (P) used for multiplications.
+type Params struct {
+	vars   *params.Params // Schemes' variables.
+	q      *Ring          // Ring of the ciphertexts.
+	p      *Ring          // Auxiliary ring.
+	qToP   *basisExtender // Conversion from Q into P.
+	pToQ   *basisExtender // Conversion from P into Q.
+	qToT   *basisExtender // Conversion from Q into the decryption modulus.
+	delta  []uint64       // floor(Q/t) mod q_i.
+	tQ     []uint64       // t mod q_i.
+	tP     []uint64       // t mod p_j.
+	qInvP  []uint64       // Q^(-1) mod p_j.
+	qInvT  uint64         // Q^(-1) mod t.
+	t      uint64         // Decryption modulus.
+	w      uint64         // Relinearization expansion base.
+	digits []int          // Number of digits in the relinearization expansion of each q_i.
+}
+
+// NewParams creates the RNS parameters from the scheme parameters and the primes
+// of the coefficient modulus. The coefficient modulus given in the scheme parameters
+// is replaced by the product of the primes.
+func NewParams(p *params.Params, moduli []uint64) (*Params, error) {
+	// Validate scheme.
+	if p.Scheme() != params.BFV && p.Scheme() != params.HERatio {
+		return nil, ErrSchemeIsNotValid
+	}
+	// Validate decryption modulus, which must fit the word-size arithmetic.
+	bt := p.DecryptionModulus()
+	if bt.Cmp(big.NewInt(1)) <= 0 || bt.BitLen() > 62 {
+		return nil, ErrDecryptionModulusIsNotValid
+	}
+	t := bt.Uint64()
+	// Validate relinearization expansion base.
+	bw := p.RelinearizationExpansionBase()
+	if bw.Cmp(big.NewInt(1)) <= 0 || !bw.IsUint64() {
+		return nil, ErrRelinearizationBaseIsNotValid
+	}
+	w := bw.Uint64()
+	// Ring of the ciphertexts.
+	rq, err := NewRing(p.Size(), moduli)
+	if err != nil {
+		return nil, err
+	}
+	if new(big.Int).GCD(nil, nil, rq.modulus, bt).Cmp(big.NewInt(1)) != 0 {
+		return nil, ErrDecryptionModulusIsNotCoprime
+	}
+	// The auxiliary basis must hold the exact tensor product of two ciphertexts
+	// and its scaling by t/Q, i.e., P > 4 * t * n * Q.
+	bitLen := rq.modulus.BitLen() + bits.Len64(t) + bits.Len(uint(p.Size())) + 2
+	count := bitLen/(auxPrimeSize-1) + 1
+	candidates, err := NTTPrimes(auxPrimeSize, p.Size(), count+len(moduli))
+	if err != nil {
+		return nil, err
+	}
+	var aux []uint64
+	for _, c := range candidates {
+		if len(aux) < count && !contains(moduli, c) {
+			aux = append(aux, c)
+		}
+	}
+	rp, err := NewRing(p.Size(), aux)
+	if err != nil {
+		return nil, err
+	}
+	// New structure.
+	np := &Params{vars: p, q: rq, p: rp, t: t, w: w}
+	np.qToP = newBasisExtender(rq, rp.moduli)
+	np.pToQ = newBasisExtender(rp, rq.moduli)
+	np.qToT = newBasisExtender(rq, []uint64{t})
+	np.delta = rq.Residues(new(big.Int).Quo(rq.modulus, bt))
+	np.tQ = rq.Residues(bt)
+	np.tP = rp.Residues(bt)
+	for _, pj := range rp.moduli {
+		bp := new(big.Int).SetUint64(pj)
+		np.qInvP = append(np.qInvP, new(big.Int).ModInverse(rq.modulus, bp).Uint64())
+	}
+	np.qInvT = new(big.Int).ModInverse(rq.modulus, bt).Uint64()
+	for _, q := range rq.moduli {
+		l := 0
+		for v := q - 1; v > 0; v /= w {
+			l++
+		}
+		np.digits = append(np.digits, l)
+	}
+	return np, nil
+}
+
+// Getter for the scheme parameters.
+func (p *Params) Vars() *params.Params {
+	return p.vars
+}
+
+// Getter for the ring of the ciphertexts.
+func (p *Params) RingQ() *Ring {
+	return p.q
+}
+
+// Getter for the coefficient modulus (product of the primes).
+func (p *Params) CoefficientModulus() *big.Int {
+	return p.q.Modulus()
+}
+
+// mul writes the product a * b in the ring of the chosen scheme into out.
+func (p *Params) mul(r *Ring, a, b, out *Poly) {
+	r.Mul(a, b, out)
+	if p.vars.Scheme() == params.HERatio {
+		// Laurent polynomials are stored with an offset of n in their powers,
+		// so the negacyclic product is multiplied by x^(-n) = -x^n.
+		r.MulMonomial(out, p.vars.Degree(), out)
+		r.Neg(out, out)
+	}
+}
+
+func contains(s []uint64, v uint64) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package rns
+
+import (
+	"math/big"
+)
+
+// Poly is a polynomial in RNS representation. Coeffs holds one row
+// of coefficients (limb) per modulus of the ring.
+type Poly struct {
+	Coeffs [][]uint64 // Limbs.
+}
+
+// Ring is the polynomial ring Z_Q[x]/(x^n + 1), where Q is the product
+// of word-sized NTT-friendly primes.
+type Ring struct {
+	n       int         // Number of coefficients.
+	moduli  []uint64    // Primes.
+	ntt     []*nttTable // Twiddle factors for each prime.
+	modulus *big.Int    // Product of the primes (Q).
+	hat     []*big.Int  // Q/q_i.
+	hatInv  []uint64    // (Q/q_i)^(-1) mod q_i.
+}
+
+// NewRing creates the ring of polynomials with n coefficients modulo the product of the given primes.
+func NewRing(n int, moduli []uint64) (*Ring, error) {
+	// Size must be a power of 2.
+	if n < 2 || n&(n-1) != 0 {
+		return nil, ErrDegreeIsNotAPowerOfTwo
+	}
+	if len(moduli) == 0 {
+		return nil, ErrModuliIsEmpty
+	}
+	r := &Ring{n: n, moduli: append([]uint64(nil), moduli...), modulus: big.NewInt(1)}
+	seen := make(map[uint64]bool, len(moduli))
+	for _, q := range moduli {
+		if seen[q] {
+			return nil, ErrModuliAreNotDistinct
+		}
+		seen[q] = true
+		t, err := newNTTTable(n, q)
+		if err != nil {
+			return nil, err
+		}
+		r.ntt = append(r.ntt, t)
+		r.modulus.Mul(r.modulus, new(big.Int).SetUint64(q))
+	}
+	// CRT constants.
+	for _, q := range moduli {
+		bq := new(big.Int).SetUint64(q)
+		h := new(big.Int).Quo(r.modulus, bq)
+		r.hat = append(r.hat, h)
+		r.hatInv = append(r.hatInv, new(big.Int).ModInverse(h, bq).Uint64())
+	}
+	return r, nil
+}
+
+// N returns the number of coefficients of the polynomials.
+func (r *Ring) N() int {
+	return r.n
+}
+
+// Moduli returns a copy of the primes of the ring.
+func (r *Ring) Moduli() []uint64 {
+	return append([]uint64(nil), r.moduli...)
+}
+
+// Modulus returns the product of the primes of the ring.
+func (r *Ring) Modulus() *big.Int {
+	return new(big.Int).Set(r.modulus)
+}
+
+// NewPoly returns a zero polynomial.
+func (r *Ring) NewPoly() *Poly {
+	p := &Poly{Coeffs: make([][]uint64, len(r.moduli))}
+	for i := range p.Coeffs {
+		p.Coeffs[i] = make([]uint64, r.n)
+	}
+	return p
+}
+
+// Copy returns a deep copy of the polynomial.
+func (r *Ring) Copy(a *Poly) *Poly {
+	p := r.NewPoly()
+	for i := range p.Coeffs {
+		copy(p.Coeffs[i], a.Coeffs[i])
+	}
+	return p
+}
+
+// SetBigInt writes the coefficients (reduced modulo each prime) into out.
+func (r *Ring) SetBigInt(coeffs []*big.Int, out *Poly) {
+	m := new(big.Int)
+	for i, q := range r.moduli {
+		bq := new(big.Int).SetUint64(q)
+		for j := 0; j < r.n; j++ {
+			out.Coeffs[i][j] = m.Mod(coeffs[j], bq).Uint64()
+		}
+	}
+}
+
+// BigInt reconstructs the coefficients through the Chinese remainder theorem
+// and returns them in the symmetric range [-Q/2, Q/2).
+func (r *Ring) BigInt(a *Poly) []*big.Int {
+	half := new(big.Int).Rsh(r.modulus, 1)
+	coeffs := make([]*big.Int, r.n)
+	t := new(big.Int)
+	for j := 0; j < r.n; j++ {
+		c := big.NewInt(0)
+		for i, q := range r.moduli {
+			y := mulMod(a.Coeffs[i][j], r.hatInv[i], q)
+			c.Add(c, t.Mul(r.hat[i], t.SetUint64(y)))
+		}
+		c.Mod(c, r.modulus)
+		if c.Cmp(half) >= 0 {
+			c.Sub(c, r.modulus)
+		}
+		coeffs[j] = c
+	}
+	return coeffs
+}
+
+// Add writes a + b into out.
+func (r *Ring) Add(a, b, out *Poly) {
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			out.Coeffs[i][j] = addMod(a.Coeffs[i][j], b.Coeffs[i][j], q)
+		}
+	}
+}
+
+// Sub writes a - b into out.
+func (r *Ring) Sub(a, b, out *Poly) {
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			out.Coeffs[i][j] = subMod(a.Coeffs[i][j], b.Coeffs[i][j], q)
+		}
+	}
+}
+
+// Neg writes -a into out.
+func (r *Ring) Neg(a, out *Poly) {
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			out.Coeffs[i][j] = subMod(0, a.Coeffs[i][j], q)
+		}
+	}
+}
+
+// MulScalar writes s * a into out, where s is given by its residue modulo each prime.
+func (r *Ring) MulScalar(a *Poly, s []uint64, out *Poly) {
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			out.Coeffs[i][j] = mulMod(a.Coeffs[i][j], s[i], q)
+		}
+	}
+}
+
+// Residues returns the residues of n modulo each prime of the ring.
+func (r *Ring) Residues(n *big.Int) []uint64 {
+	res := make([]uint64, len(r.moduli))
+	m := new(big.Int)
+	for i, q := range r.moduli {
+		res[i] = m.Mod(n, new(big.Int).SetUint64(q)).Uint64()
+	}
+	return res
+}
+
+// Mul writes the negacyclic product a * b mod (x^n + 1) into out.
+func (r *Ring) Mul(a, b, out *Poly) {
+	fa := make([]uint64, r.n)
+	fb := make([]uint64, r.n)
+	for i, q := range r.moduli {
+		copy(fa, a.Coeffs[i])
+		copy(fb, b.Coeffs[i])
+		r.ntt[i].forward(fa)
+		r.ntt[i].forward(fb)
+		for j := 0; j < r.n; j++ {
+			fa[j] = mulMod(fa[j], fb[j], q)
+		}
+		r.ntt[i].inverse(fa)
+		copy(out.Coeffs[i], fa)
+	}
+}
+
+// MulMonomial writes a * x^k mod (x^n + 1) into out, for 0 <= k < n.
+func (r *Ring) MulMonomial(a *Poly, k int, out *Poly) {
+	c := make([]uint64, r.n)
+	for i, q := range r.moduli {
+		for j := 0; j < r.n; j++ {
+			if j+k < r.n {
+				c[j+k] = a.Coeffs[i][j]
+			} else {
+				c[j+k-r.n] = subMod(0, a.Coeffs[i][j], q)
+			}
+		}
+		copy(out.Coeffs[i], c)
+	}
+}
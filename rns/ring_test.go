@@ -0,0 +1,118 @@
+package rns
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
+)
+
+func TestNTTPrimes(t *testing.T) {
+	// Case: primes are distinct, below the bit size and equal to 1 mod 2n.
+	n := 2048
+	primes, err := NTTPrimes(55, n, 4)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(primes) != 4 {
+		t.Errorf("expected %d primes but got %d", 4, len(primes))
+	}
+	for i, q := range primes {
+		if q >= 1<<55 || (q-1)%uint64(2*n) != 0 || !new(big.Int).SetUint64(q).ProbablyPrime(20) {
+			t.Errorf("%d is not an NTT-friendly prime", q)
+		}
+		if i > 0 && q >= primes[i-1] {
+			t.Errorf("primes should be distinct and decreasing")
+		}
+	}
+	// Case: not enough primes.
+	if _, err := NTTPrimes(12, n, 10); err != ErrNotEnoughPrimes {
+		t.Errorf("expected error %s", ErrNotEnoughPrimes)
+	}
+	// Case: invalid ring size.
+	if _, err := NewRing(12, primes); err != ErrDegreeIsNotAPowerOfTwo {
+		t.Errorf("expected error %s", ErrDegreeIsNotAPowerOfTwo)
+	}
+	// Case: modulus that is not NTT-friendly.
+	if _, err := NewRing(n, []uint64{9_876_523_525}); err != ErrModulusIsNotNTTFriendly {
+		t.Errorf("expected error %s", ErrModulusIsNotNTTFriendly)
+	}
+	// Case: repeated moduli.
+	if _, err := NewRing(n, []uint64{primes[0], primes[0]}); err != ErrModuliAreNotDistinct {
+		t.Errorf("expected error %s", ErrModuliAreNotDistinct)
+	}
+}
+
+func TestRingMul(t *testing.T) {
+	// Case: the limb-wise NTT product matches the negacyclic product over the integers.
+	n := 64
+	primes, err := NTTPrimes(50, n, 3)
+	if err != nil {
+		t.Error(err)
+	}
+	r, err := NewRing(n, primes)
+	if err != nil {
+		t.Error(err)
+	}
+	// Random polynomials.
+	o := new(oracle.Oracle)
+	x, err := o.RandInt(-1<<40, 1<<40, n)
+	if err != nil {
+		t.Error(err)
+	}
+	y, err := o.RandInt(-1<<40, 1<<40, n)
+	if err != nil {
+		t.Error(err)
+	}
+	a, b, c := r.NewPoly(), r.NewPoly(), r.NewPoly()
+	r.SetBigInt(x, a)
+	r.SetBigInt(y, b)
+	r.Mul(a, b, c)
+	prod := r.BigInt(c)
+	// Expected product.
+	for k := 0; k < n; k++ {
+		e := big.NewInt(0)
+		for i := 0; i < n; i++ {
+			j := k - i
+			m := new(big.Int)
+			if j >= 0 {
+				e.Add(e, m.Mul(x[i], y[j]))
+			} else {
+				e.Sub(e, m.Mul(x[i], y[j+n]))
+			}
+		}
+		if e.Cmp(prod[k]) != 0 {
+			t.Errorf("expected %s at position [%d] but got %s", e.String(), k, prod[k].String())
+			break
+		}
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	// Case: CRT reconstruction recovers values in the symmetric range.
+	n := 16
+	primes, err := NTTPrimes(61, n, 4)
+	if err != nil {
+		t.Error(err)
+	}
+	r, err := NewRing(n, primes)
+	if err != nil {
+		t.Error(err)
+	}
+	half := new(big.Int).Rsh(r.Modulus(), 1)
+	coeffs := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		// Values spread over [-Q/2, Q/2).
+		coeffs[i] = new(big.Int).Div(new(big.Int).Mul(half, big.NewInt(int64(2*i-n))), big.NewInt(int64(n)))
+	}
+	coeffs[n-1] = new(big.Int).Sub(half, big.NewInt(1))
+	a := r.NewPoly()
+	r.SetBigInt(coeffs, a)
+	rc := r.BigInt(a)
+	for i := 0; i < n; i++ {
+		if rc[i].Cmp(coeffs[i]) != 0 {
+			t.Errorf("expected %s at position [%d] but got %s", coeffs[i].String(), i, rc[i].String())
+			break
+		}
+	}
+}
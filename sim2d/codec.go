@@ -121,10 +121,24 @@ func dotProd(v1 []*big.Rat, v2 []*big.Int) *big.Rat {
 	return dp
 }
 
+// roundUp rounds r up to the lowest power of the expansion, b^MinPow. The
+// scaling is done with big numbers, since b^-MinPow overflows a float64 for
+// large degrees.
 func roundUp(r float64, p *Params) float64 {
+	// An infinite rational cannot be rounded.
+	if math.IsInf(r, 0) {
+		return r
+	}
 	// Base to the power of the absolute value of p.
-	b := math.Pow(float64(p.Base()), float64(-p.MinPow()))
-	// Digit.
-	d := b * r
-	return math.Ceil(d) / b
+	b := new(big.Int).Exp(big.NewInt(p.Base()), big.NewInt(int64(-p.MinPow())), nil)
+	// Digit, which is exact with the precision of r and b.
+	d := new(big.Float).SetPrec(53 + uint(b.BitLen())).SetFloat64(r)
+	d.Mul(d, new(big.Float).SetInt(b))
+	// Ceiling, from the truncation towards zero.
+	n, acc := d.Int(nil)
+	if acc == big.Below {
+		n.Add(n, big.NewInt(1))
+	}
+	f, _ := new(big.Rat).SetFrac(n, b).Float64()
+	return f
 }
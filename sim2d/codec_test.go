@@ -1,6 +1,7 @@
 package sim2d
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
@@ -31,3 +32,52 @@ func TestEncDec(t *testing.T) {
 		t.Errorf("expected result was %f but got %f", r, rr)
 	}
 }
+
+func TestEncDecLargeDegree(t *testing.T) {
+	// Case: parameters whose fractional positions exceed the range of a float64
+	// (10^-512 and 2^-1024).
+	for _, pl := range []params.Literal{params.PLBFV1024, params.PLBFV2048} {
+		testEncDec(t, pl, []float64{12345.678, -0.75, 351.179, -198.26, 1e-30})
+	}
+}
+
+func TestRoundUp(t *testing.T) {
+	// Create parameters.
+	p, err := params.New(params.PLBFV1024)
+	if err != nil {
+		t.Error(err)
+	}
+	v, err := newParams(p)
+	if err != nil {
+		t.Error(err)
+	}
+	// Case: b^-MinPow = 10^512 overflows a float64, and rationals are kept.
+	for _, r := range []float64{12345.678, -0.75, math.Inf(1)} {
+		if rr := roundUp(r, v); rr != r {
+			t.Errorf("expected %f but got %f", r, rr)
+		}
+	}
+}
+
+func testEncDec(t *testing.T, pl params.Literal, rs []float64) {
+	p, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	// SIM2D codec.
+	sc, err := New(p)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, r := range rs {
+		// Decoded number.
+		rr, err := sc.Dec(sc.Enc(r))
+		if err != nil {
+			t.Error(err)
+		}
+		// Check result.
+		if rr != r {
+			t.Errorf("expected result was %f but got %f", r, rr)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package scheme
 
 import (
-	"math"
 	"math/big"
 
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
@@ -36,6 +35,122 @@ func Conv(f, g []*big.Int, params *params.Params) ([]*big.Int, error) {
 	return c, nil
 }
 
+const (
+	// KaratsubaThreshold is the polynomial size from which BFVPolyMult and HERatioPolyMult
+	// use the Karatsuba convolution instead of the schoolbook one.
+	KaratsubaThreshold = 32
+	// karatsubaBaseCase is the vector length at which the Karatsuba recursion stops.
+	karatsubaBaseCase = 16
+)
+
+// KaratsubaConv calculates the same convolution as Conv through the recursive
+// Karatsuba algorithm, which takes O(n^1.58) multiplications instead of O(n^2).
+func KaratsubaConv(f, g []*big.Int, params *params.Params) ([]*big.Int, error) {
+	n := params.Size()
+	return karatsuba(f[:n], g[:n]), nil
+}
+
+// karatsuba returns the linear convolution of two vectors with the same length.
+func karatsuba(f, g []*big.Int) []*big.Int {
+	c := newInts(2*len(f) - 1)
+	karatsubaInto(c, f, g, newInts(karatsubaWorkspace(len(f))))
+	return c
+}
+
+// karatsubaWorkspace returns the number of temporary integers that karatsubaInto
+// needs for vectors of length n.
+func karatsubaWorkspace(n int) int {
+	if n <= karatsubaBaseCase {
+		return 1
+	}
+	m := n - n/2
+	return 4*m - 1 + karatsubaWorkspace(m)
+}
+
+// karatsubaInto sets c[:2n-1] to the linear convolution of f and g, which have the
+// same length n, with the temporary integers of ws (see karatsubaWorkspace).
+// The vector c must not overlap f, g and ws.
+func karatsubaInto(c, f, g, ws []*big.Int) {
+	n := len(f)
+	// Small vectors are multiplied through the schoolbook algorithm.
+	if n <= karatsubaBaseCase {
+		schoolbookInto(c, f, g, ws[0])
+		return
+	}
+	// Split vectors into lower (0) and higher (1) halves.
+	h := n / 2
+	m := n - h
+	f0, f1 := f[:h], f[h:]
+	g0, g1 := g[:h], g[h:]
+	// Sum of the halves (the higher half has m >= h coefficients).
+	fs, gs, z1, ws := ws[:m], ws[m:2*m], ws[2*m:4*m-1], ws[4*m-1:]
+	for i := 0; i < m; i++ {
+		fs[i].Set(f1[i])
+		gs[i].Set(g1[i])
+		if i < h {
+			fs[i].Add(fs[i], f0[i])
+			gs[i].Add(gs[i], g0[i])
+		}
+	}
+	// z0 = f0*g0 and z2 = f1*g1 go to their place in c, and
+	// z1 = (f0+f1)*(g0+g1) - z0 - z2.
+	z0, z2 := c[:2*h-1], c[2*h:2*n-1]
+	karatsubaInto(z0, f0, g0, ws)
+	karatsubaInto(z2, f1, g1, ws)
+	c[2*h-1].SetInt64(0)
+	karatsubaInto(z1, fs, gs, ws)
+	for i := 0; i < len(z0); i++ {
+		z1[i].Sub(z1[i], z0[i])
+	}
+	for i := 0; i < len(z2); i++ {
+		z1[i].Sub(z1[i], z2[i])
+	}
+	// c = z0 + z1*x^h + z2*x^(2h).
+	for i := 0; i < len(z1); i++ {
+		c[i+h].Add(c[i+h], z1[i])
+	}
+}
+
+// schoolbook returns the linear convolution of two vectors with the same length.
+func schoolbook(f, g []*big.Int) []*big.Int {
+	c := newInts(2*len(f) - 1)
+	schoolbookInto(c, f, g, new(big.Int))
+	return c
+}
+
+// schoolbookInto sets c[:2n-1] to the linear convolution of f and g, which have
+// the same length n, with the temporary integer m. The vector c must not overlap
+// f and g.
+func schoolbookInto(c, f, g []*big.Int, m *big.Int) {
+	n := len(f)
+	for i := 0; i < 2*n-1; i++ {
+		c[i].SetInt64(0)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m.Mul(f[i], g[j])
+			c[i+j].Add(c[i+j], m)
+		}
+	}
+}
+
+// newInts returns n new integers set to zero.
+func newInts(n int) []*big.Int {
+	v := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		v[i] = new(big.Int)
+	}
+	return v
+}
+
+// conv selects the convolution algorithm based on the size of the polynomials.
+func conv(f, g []*big.Int, params *params.Params) ([]*big.Int, error) {
+	if params.Size() >= KaratsubaThreshold {
+		return KaratsubaConv(f, g, params)
+	}
+	return Conv(f, g, params)
+}
+
 func VecSymMod(z []*big.Int, m *big.Int) []*big.Int {
 	v := []*big.Int{}
 	for i := 0; i < len(z); i++ {
@@ -55,14 +170,9 @@ func SumZip(x, y []*big.Int, p *params.Params) []*big.Int {
 	return sum
 }
 
-func CoeffExpLen(p *params.Params) int {
-	logQ := math.Log2(float64(p.CoefficientModulus()))
-	logW := math.Log2(float64(p.RelinearizationExpansionBase()))
-	return int(math.Floor(logQ/logW)) + 1
-}
-
+// Delta returns floor(q/t).
 func Delta(p *params.Params) *big.Int {
-	return big.NewInt(p.CoefficientModulus() / p.DecryptionModulus())
+	return new(big.Int).Quo(p.CoefficientModulus(), p.DecryptionModulus())
 }
 
 // DivRound divides a number (*big.Int) and rounds based on the remainder.
@@ -95,8 +205,8 @@ func DivRound(n, d *big.Int) *big.Int {
 }
 
 func Func1(c []*big.Int, p *params.Params) []*big.Int {
-	dm := big.NewInt(p.DecryptionModulus())
-	cm := big.NewInt(p.CoefficientModulus())
+	dm := p.DecryptionModulus()
+	cm := p.CoefficientModulus()
 	// Loop.
 	v := []*big.Int{}
 	for i := 0; i < len(c); i++ {
@@ -111,7 +221,7 @@ func Func1(c []*big.Int, p *params.Params) []*big.Int {
 
 func Func345(prodIndex []*big.Int, prod [][]*big.Int, p *params.Params) []*big.Int {
 	// Function 3.
-	l := CoeffExpLen(p)
+	l := p.RelinearizationDigits()
 
 	// Function 4.
 	ss := make([]*big.Int, p.Size())
@@ -126,7 +236,20 @@ func Func345(prodIndex []*big.Int, prod [][]*big.Int, p *params.Params) []*big.I
 	return ss
 }
 
+// PolyMult multiplies two polynomials in the ring of the chosen scheme.
+// When the coefficient modulus is NTT-friendly the product is calculated through
+// the number-theoretic transform and is therefore only exact modulo the coefficient
+// modulus. Otherwise, it falls back to IntPolyMult.
 func PolyMult(x, y []*big.Int, p *params.Params) ([]*big.Int, error) {
+	if IsNTTFriendly(p) {
+		return NTTPolyMult(x, y, p)
+	}
+	return IntPolyMult(x, y, p)
+}
+
+// IntPolyMult multiplies two polynomials in the ring of the chosen scheme
+// without any reduction of the coefficients (exact integer product).
+func IntPolyMult(x, y []*big.Int, p *params.Params) ([]*big.Int, error) {
 	var prod []*big.Int
 	var err error
 	switch p.Scheme() {
@@ -144,7 +267,7 @@ func HERatioPolyMult(x, y []*big.Int, params *params.Params) ([]*big.Int, error)
 	// Set degree from parameters.
 	n := params.Degree()
 	// Convolution.
-	p, err := Conv(x, y, params)
+	p, err := conv(x, y, params)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +300,7 @@ func BFVPolyMult(x, y []*big.Int, params *params.Params) ([]*big.Int, error) {
 	// Set degree from parameters.
 	n := params.Size()
 	// Convolution.
-	c, err := Conv(x, y, params)
+	c, err := conv(x, y, params)
 	if err != nil {
 		return nil, err
 	}
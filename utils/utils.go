@@ -2,6 +2,7 @@ package utils
 
 import (
 	"math/big"
+	"sort"
 )
 
 // SymMod calculates the symmetric modulo.
@@ -30,56 +31,78 @@ func SymMod(n, b *big.Int) *big.Int {
 	return r
 }
 
+// two is the only base without positive symmetric digits.
+var two = big.NewInt(2)
+
 // Exp calculates the expansion.
 func Exp(m *big.Int, l int, b int64) []*big.Int {
+	return BigExp(m, l, big.NewInt(b))
+}
+
+// BigExp calculates the expansion in an arbitrarily large base.
+func BigExp(m *big.Int, l int, b *big.Int) []*big.Int {
 	// Expansion.
 	exp := []*big.Int{}
-	// Relinearization expansion base.
-	reb := b
 	// Zero for comparison.
 	z := big.NewInt(0)
 	// Input.
 	in := big.NewInt(0)
 	in.Add(in, m)
+	// b^i, starting at b^0.
+	bi := big.NewInt(1)
 	for i := 0; i < l; i++ {
-		// b^i.
-		bi := big.NewInt(reb)
-		bi.Exp(bi, big.NewInt(int64(i)), nil)
-		// b.
-		bb := big.NewInt(reb)
 		// floor(input/b^i).
 		inSM := big.NewInt(0)
 		inSM.Div(in, bi)
 		// Symmetric modulo.
-		sm := SymMod(inSM, bb)
+		sm := SymMod(inSM, b)
+		// In base 2, the symmetric digits are -1 and 0, with which a positive
+		// input never ends, so the digit 1 is kept instead.
+		if b.Cmp(two) == 0 && sm.Sign() < 0 && inSM.Sign() > 0 {
+			sm.Neg(sm)
+		}
 		// Expansion.
 		exp = append(exp, sm)
+		// b^i+1
+		bi.Mul(bi, b)
 		if sm.Cmp(z) == -1 {
-			// b^i+1
-			bi1 := big.NewInt(reb)
-			bi1.Exp(bi1, big.NewInt(int64(i+1)), nil)
 			// input+b^(i+1)
-			in.Add(in, bi1)
+			in.Add(in, bi)
 		}
 	}
 	return exp
 }
 
 // Num returns the equivalent numerator for a given rational
-// based on the base and degree.
+// based on the base and degree. It is r * b^e with the fewest digits
+// after the point in base b that still give back r as a float64,
+// so that r = 12345.678 has 3 digits after the point in base 10.
 func Num(r float64, b, e int64) *big.Int {
-	// Big float.
-	n := big.NewFloat(r)
-	bf := big.NewFloat(0.0)
-	// Exponent.
-	eb := big.NewInt(e)
+	// Big rational, which is exact.
+	n := new(big.Rat).SetFloat64(r)
+	// Fewest digits k, by binary search: with more digits, the
+	// rounding of r * b^k can only get closer to r.
+	k := int64(sort.Search(int(e), func(k int) bool {
+		num, den := roundNum(n, b, int64(k))
+		f, _ := new(big.Rat).SetFrac(num, den).Float64()
+		return f == r
+	}))
 	// Numerator (*big.Int).
+	num, _ := roundNum(n, b, k)
 	bb := big.NewInt(b)
-	bb.Exp(bb, eb, nil)
-	bf.SetInt(bb)
-	n.Mul(n, bf)
+	bb.Exp(bb, big.NewInt(e-k), nil)
+	return num.Mul(num, bb)
+}
 
-	var num *big.Int
-	num, _ = n.Int(num)
-	return num
+// roundNum returns r * b^k rounded to the nearest integer (halves away
+// from zero), and b^k.
+func roundNum(r *big.Rat, b, k int64) (*big.Int, *big.Int) {
+	bk := big.NewInt(b)
+	bk.Exp(bk, big.NewInt(k), nil)
+	x := new(big.Rat).Mul(r, new(big.Rat).SetInt(bk))
+	num, rem := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).CmpAbs(x.Denom()) >= 0 {
+		num.Add(num, big.NewInt(int64(rem.Sign())))
+	}
+	return num, bk
 }
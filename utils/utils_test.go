@@ -2,6 +2,7 @@ package utils
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +49,79 @@ func TestExp(t *testing.T) {
 		}
 	}
 }
+
+func TestBigExp(t *testing.T) {
+	// Base larger than 64 bits.
+	b := new(big.Int).Lsh(big.NewInt(1), 70)
+	b.Add(b, big.NewInt(3))
+	// Degree.
+	d := 5
+	// Input values of hundreds of bits with both signs.
+	m := new(big.Int).Lsh(big.NewInt(1), 300)
+	m.Sub(m, big.NewInt(12345))
+	for _, n := range []*big.Int{m, new(big.Int).Neg(m)} {
+		// Calculate expansion.
+		exp := BigExp(n, d, b)
+		if len(exp) != d {
+			t.Errorf("expected expansion has %d elements but got %d", d, len(exp))
+		}
+		// The expansion must recompose the input: sum(exp[i] * b^i).
+		r, bi := big.NewInt(0), big.NewInt(1)
+		for i := 0; i < len(exp); i++ {
+			r.Add(r, new(big.Int).Mul(exp[i], bi))
+			bi.Mul(bi, b)
+		}
+		if r.Cmp(n) != 0 {
+			t.Errorf("expected %s but got %s", n.String(), r.String())
+		}
+	}
+}
+
+func TestNum(t *testing.T) {
+	// Case: numerators of messages with few digits are exact for large exponents.
+	tests := []struct {
+		r float64
+		e int64
+		n string
+	}{
+		{0.75, 32, "75" + strings.Repeat("0", 30)},
+		{-99.25, 40, "-9925" + strings.Repeat("0", 38)},
+		{4, 512, "4" + strings.Repeat("0", 512)},
+	}
+	for _, tt := range tests {
+		if n := Num(tt.r, 10, tt.e); n.String() != tt.n {
+			t.Errorf("expected %s for %f with exponent %d but got %s", tt.n, tt.r, tt.e, n.String())
+		}
+	}
+	// Case: messages keep the fewest digits that give them back, i.e., the
+	// digits they were written with in base 10.
+	if n := Num(12345.678, 10, 16).String(); n != "123456780000000000000" {
+		t.Errorf("expected %s but got %s", "123456780000000000000", n)
+	}
+	// Case: in base 2, these are the digits of the float64 itself.
+	x := new(big.Rat).SetFloat64(351.179)
+	x.Mul(x, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 1024)))
+	if n := Num(351.179, 2, 1024); n.Cmp(x.Num()) != 0 {
+		t.Errorf("expected %s but got %s", x.Num().String(), n.String())
+	}
+}
+
+func TestExpBaseTwo(t *testing.T) {
+	// Case: positive and negative inputs end in base 2 and recompose the input.
+	b := big.NewInt(2)
+	d := 16
+	for _, v := range []int64{1, 3, 5, 1000, -1, -3, -1000} {
+		exp := Exp(big.NewInt(v), d, 2)
+		r, bi := big.NewInt(0), big.NewInt(1)
+		for i := 0; i < len(exp); i++ {
+			if exp[i].CmpAbs(big.NewInt(1)) > 0 {
+				t.Errorf("expected a digit of at most 1 but got %s", exp[i].String())
+			}
+			r.Add(r, new(big.Int).Mul(exp[i], bi))
+			bi.Mul(bi, b)
+		}
+		if r.Int64() != v {
+			t.Errorf("expected %d but got %s", v, r.String())
+		}
+	}
+}
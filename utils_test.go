@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Algemetric/HERatio/Implementation/Golang/oracle"
 	"github.com/Algemetric/HERatio/Implementation/Golang/params"
 )
 
@@ -23,6 +24,7 @@ func TestBFVPolyMult(t *testing.T) {
 	pl := params.PLHERatio16
 	pl.Degree = 8
 	pl.Factor = 1
+	pl.Scheme = params.BFV
 	p, err := params.New(pl)
 	if err != nil {
 		t.Error(err)
@@ -54,6 +56,7 @@ func TestConv(t *testing.T) {
 	// Parameters.
 	pl := params.PLHERatio16
 	pl.Factor = 1
+	pl.Scheme = params.BFV
 	p, err := params.New(pl)
 	if err != nil {
 		t.Error(err)
@@ -89,6 +92,65 @@ func TestConv(t *testing.T) {
 	}
 }
 
+func TestKaratsubaConv(t *testing.T) {
+	// Case: Karatsuba and schoolbook convolutions agree for several sizes.
+	o := new(oracle.Oracle)
+	for _, d := range []int{8, 16, 32, 64, 256} {
+		// Parameters.
+		pl := params.PLBFV32
+		pl.Degree = d
+		p, err := params.New(pl)
+		if err != nil {
+			t.Error(err)
+		}
+		// Random polynomials.
+		x, err := o.RandInt(-params.CoefficientModulus/2, params.CoefficientModulus/2, p.Size())
+		if err != nil {
+			t.Error(err)
+		}
+		y, err := o.RandInt(-params.CoefficientModulus/2, params.CoefficientModulus/2, p.Size())
+		if err != nil {
+			t.Error(err)
+		}
+		// Vector results.
+		r, err := KaratsubaConv(x, y, p)
+		if err != nil {
+			t.Error(err)
+		}
+		er, err := Conv(x, y, p)
+		if err != nil {
+			t.Error(err)
+		}
+		// Check size.
+		if len(r) != len(er) {
+			t.Errorf("resulting vector has size %d, but %d was expected", len(r), len(er))
+		}
+		// Check results.
+		for i := 0; i < len(er); i++ {
+			if r[i].Cmp(er[i]) != 0 {
+				t.Errorf("expected %s at position [%d] for degree %d but got %s", er[i].String(), i, d, r[i].String())
+				break
+			}
+		}
+	}
+	// Case: vectors with odd lengths split into unequal halves.
+	x := []int64{3, -1, 4, 1, -5, 9, 2, -6, 5, 3, -5, 8, 9, -7, 9, 3, 2, -3, 8, 4, -6, 2, 6, 4, 3, -3, 8, 3, 2, 7, -9, 5, 0}
+	y := []int64{2, 7, -1, 8, 2, 8, -1, 8, 2, 8, 4, -5, 9, 0, 4, 5, 2, -3, 5, 3, 6, 0, 2, 8, -7, 4, 7, 1, 3, 5, 2, 6, -6}
+	xb, yb := make([]*big.Int, len(x)), make([]*big.Int, len(y))
+	for i := 0; i < len(x); i++ {
+		xb[i] = big.NewInt(x[i])
+		yb[i] = big.NewInt(y[i])
+	}
+	r := karatsuba(xb, yb)
+	er := schoolbook(xb, yb)
+	for i := 0; i < len(er); i++ {
+		if r[i].Cmp(er[i]) != 0 {
+			t.Errorf("expected %s at position [%d] but got %s", er[i].String(), i, r[i].String())
+			break
+		}
+	}
+}
+
 func TestVecSymMod(t *testing.T) {
 	// Input.
 	z := []int64{3, -5, -22, 14, -8, 6, 12, -20}
@@ -180,12 +242,12 @@ func TestDivRound(t *testing.T) {
 	}
 	// Expected rounded results.
 	er := []int64{4721761431118, -15106611771466, -8014272868898, 1890396034204, 3263855587490, -791785794691, 2964743107740, -9992323348830, -1281200989406, -2447027892571, -1819048900497, 13862537962578, 491866113925, -5568247289001, 740628004204, -12816130158872, 3449658993313, -5783016850987, -3657031971008, 8326882920248, 1381660330179, -2959201840786, -5047034282872, -12235168488468, 11832628885282, 3899704320913, 1334969335098, -2368545255060, -9982499385614, 300458840818, -18258932470738, 737719435721}
-	cmb := big.NewInt(p.CoefficientModulus())
+	cmb := p.CoefficientModulus()
 	// Calculate rounded values.
 	for i := 0; i < len(er); i++ {
 		// Rounded values.
 		erb := big.NewInt(er[i])
-		num := big.NewInt(p.DecryptionModulus())
+		num := p.DecryptionModulus()
 		num.Mul(num, pmb[i])
 		pmr := DivRound(num, cmb)
 		if erb.Cmp(pmr) != 0 {
@@ -195,3 +257,22 @@ func TestDivRound(t *testing.T) {
 
 	}
 }
+
+func TestDelta(t *testing.T) {
+	// Case: q with hundreds of bits.
+	// Parameters.
+	pl := params.PLBFV32
+	pl.CoefficientModulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	p, err := params.New(pl)
+	if err != nil {
+		t.Error(err)
+	}
+	// floor(q/t) * t + (q mod t) = q.
+	d := Delta(p)
+	r := new(big.Int).Mod(pl.CoefficientModulus, pl.DecryptionModulus)
+	d.Mul(d, pl.DecryptionModulus)
+	d.Add(d, r)
+	if d.Cmp(pl.CoefficientModulus) != 0 {
+		t.Errorf("expected %s but got %s", pl.CoefficientModulus.String(), d.String())
+	}
+}